@@ -16,9 +16,6 @@ limitations under the License.
 package backup
 
 import (
-	"fmt"
-	"time"
-
 	"github.com/dcjulian29/proxmoxctl/cmd/backup/job"
 	"github.com/spf13/cobra"
 )
@@ -65,75 +62,3 @@ Examples:
 
 	return cmd
 }
-
-func formatBool(v any) string {
-	if v == nil {
-		return "false"
-	}
-
-	switch val := v.(type) {
-	case bool:
-		if val {
-			return "true"
-		}
-
-		return "false"
-	case float64:
-		if val == 1 {
-			return "true"
-		}
-
-		return "false"
-	}
-
-	return "false"
-}
-
-func formatBytes(b float64) string {
-	const unit = 1024
-	if b < unit {
-		return fmt.Sprintf("%.0f B", b)
-	}
-
-	div, exp := float64(unit), 0
-
-	for n := b / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-
-	return fmt.Sprintf("%.1f %cB", b/div, "KMGTPE"[exp])
-}
-
-func formatEpoch(v any) string {
-	if v == nil {
-		return ""
-	}
-
-	if val, ok := v.(float64); ok && val > 0 {
-		return time.Unix(int64(val), 0).Format("2006-01-02 15:04:05")
-	}
-
-	return ""
-}
-
-func toFloat(v any) float64 {
-	if v == nil {
-		return 0
-	}
-
-	switch val := v.(type) {
-	case float64:
-		return val
-	}
-
-	return 0
-}
-
-func toString(v any) string {
-	if v == nil {
-		return ""
-	}
-
-	return fmt.Sprintf("%v", v)
-}
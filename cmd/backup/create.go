@@ -16,16 +16,22 @@ limitations under the License.
 package backup
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
 func createCmd() *cobra.Command {
 	var (
 		node        string
+		allNodes    bool
 		storage     string
 		mode        string
 		compress    string
@@ -35,64 +41,89 @@ func createCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "create <vmid>[,vmid,...]",
+		Use:   "create <vmid|name>[,vmid|name,...]",
 		Short: "Create an on-demand backup of one or more guests",
-		Args:  cobra.ExactArgs(1),
+		Long: `Create an on-demand backup of one or more guests.
+
+Guests given by VMID or name are backed up on the node that owns them, with
+one vzdump run per node. "all" backs up every guest on a single node: --node,
+or the default node when it is omitted. Add --all-nodes to back up every
+guest in the cluster instead.
+
+Examples:
+  proxmoxctl backup create 100 --storage local
+  proxmoxctl backup create web01,db01 --storage backup-nfs
+  proxmoxctl backup create all --node pve2 --storage backup-nfs
+  proxmoxctl backup create all --all-nodes --storage backup-nfs`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			if allNodes && args[0] != "all" {
+				return fmt.Errorf("--all-nodes only applies to `backup create all`")
+			}
+
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
-				}
+			targets, err := backupTargets(cmd.Context(), client, args[0], node, allNodes)
+			if err != nil {
+				return err
 			}
 
-			vmids := args[0]
+			for _, t := range targets {
+				payload := map[string]any{
+					"storage":  storage,
+					"mode":     mode,
+					"compress": compress,
+				}
 
-			payload := map[string]any{
-				"storage":  storage,
-				"mode":     mode,
-				"compress": compress,
-			}
+				guests := "all"
 
-			if vmids == "all" {
-				payload["all"] = 1
-			} else {
-				payload["vmid"] = vmids
-			}
+				if t.vmids == nil {
+					payload["all"] = 1
+				} else {
+					guests = strings.Join(t.vmids, ",")
+					payload["vmid"] = guests
+				}
 
-			if mailTo != "" {
-				payload["mailto"] = mailTo
-			}
+				if mailTo != "" {
+					payload["mailto"] = mailTo
+				}
 
-			if notes != "" {
-				payload["notes-template"] = notes
-			}
+				if notes != "" {
+					payload["notes-template"] = notes
+				}
 
-			if removeOlder > 0 {
-				payload["remove"] = removeOlder
-			}
+				if removeOlder > 0 {
+					payload["remove"] = removeOlder
+				}
 
-			var resp any
+				upid, err := client.Nodes.Vzdump(cmd.Context(), t.node, payload)
+				if err != nil {
+					return err
+				}
 
-			if err := client.Post(fmt.Sprintf("/nodes/%s/vzdump", node), payload, &resp); err != nil {
-				return err
-			}
+				if err := client.Await(cmd.Context(), t.node, upid); err != nil {
+					return err
+				}
 
-			output.Success(fmt.Sprintf(
-				"Backup task queued for guest(s) %s on node %s → storage: %s (mode: %s, compress: %s)",
-				vmids, node, storage, mode, compress,
-			))
+				output.Done(output.Result{
+					Message: fmt.Sprintf(
+						"Backup task %s for guest(s) %s on node %s → storage: %s (mode: %s, compress: %s)",
+						api.TaskState(), guests, t.node, storage, mode, compress,
+					),
+					UPID: upid,
+					Node: t.node,
+				})
+			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&node, "node", "", "Proxmox node (auto-detected if not set)")
+	cmd.Flags().StringVar(&node, "node", "", "Run vzdump on this node (default: each guest's owning node, or the default node for all)")
+	cmd.Flags().BoolVar(&allNodes, "all-nodes", false, "With all, back up every guest in the cluster, one vzdump per node")
 	cmd.Flags().StringVar(&storage, "storage", "", "Target storage for the backup file (required)")
 	cmd.Flags().StringVar(&mode, "mode", "snapshot", "Backup mode: snapshot, suspend, or stop")
 	cmd.Flags().StringVar(&compress, "compress", "zstd", "Compression: zstd, lzo, gzip, or 0 (none)")
@@ -102,5 +133,103 @@ func createCmd() *cobra.Command {
 
 	_ = cmd.MarkFlagRequired("storage")
 
+	cmd.MarkFlagsMutuallyExclusive("node", "all-nodes")
+
 	return cmd
 }
+
+// backupTarget is one vzdump run: the guests to back up on a node, or
+// every guest on it when vmids is nil.
+type backupTarget struct {
+	node  string
+	vmids []string
+}
+
+// backupTargets splits a comma-separated list of VMIDs and guest names into
+// one vzdump run per owning node, since vzdump skips guests the node does
+// not own. An explicit node takes every guest as given. "all" covers one
+// node, or with allNodes every node that owns a guest.
+func backupTargets(ctx context.Context, client *api.Client, list, node string, allNodes bool) ([]backupTarget, error) {
+	if list == "all" && allNodes {
+		resources, err := client.Cluster.Resources(ctx, "vm")
+		if err != nil {
+			return nil, err
+		}
+
+		return nodeTargets(resources), nil
+	}
+
+	if list == "all" {
+		if node == "" {
+			var err error
+
+			if node, err = client.DefaultNode(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		return []backupTarget{{node: node}}, nil
+	}
+
+	refs := strings.Split(list, ",")
+
+	if node != "" {
+		t := backupTarget{node: node}
+
+		for _, ref := range refs {
+			g, err := client.ResolveGuest(ctx, ref, node, "")
+			if err != nil {
+				return nil, err
+			}
+
+			t.vmids = append(t.vmids, strconv.Itoa(g.VMID))
+		}
+
+		return []backupTarget{t}, nil
+	}
+
+	guests, err := client.LocateGuests(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return guestTargets(guests), nil
+}
+
+// guestTargets groups guests by owning node, keeping the order in which
+// each node and guest first appears.
+func guestTargets(guests []*api.Guest) []backupTarget {
+	var targets []backupTarget
+
+	for _, g := range guests {
+		i := slices.IndexFunc(targets, func(t backupTarget) bool { return t.node == g.Node })
+		if i < 0 {
+			targets = append(targets, backupTarget{node: g.Node})
+			i = len(targets) - 1
+		}
+
+		targets[i].vmids = append(targets[i].vmids, strconv.Itoa(g.VMID))
+	}
+
+	return targets
+}
+
+// nodeTargets returns a whole-node target for every node that owns a guest,
+// sorted by node name.
+func nodeTargets(resources []proxmox.ClusterResource) []backupTarget {
+	var targets []backupTarget
+
+	for _, r := range resources {
+		if r.Type != "qemu" && r.Type != "lxc" {
+			continue
+		}
+
+		if !slices.ContainsFunc(targets, func(t backupTarget) bool { return t.node == r.Node }) {
+			targets = append(targets, backupTarget{node: r.Node})
+		}
+	}
+
+	slices.SortFunc(targets, func(a, b backupTarget) int { return strings.Compare(a.node, b.node) })
+
+	return targets
+}
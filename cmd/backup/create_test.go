@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package backup
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+)
+
+func TestGuestTargets(t *testing.T) {
+	guests := []*api.Guest{
+		{VMID: 101, Node: "pve2"},
+		{VMID: 100, Node: "pve1"},
+		{VMID: 200, Node: "pve2", Type: "lxc"},
+		{VMID: 102, Node: "pve1"},
+	}
+
+	want := []backupTarget{
+		{node: "pve2", vmids: []string{"101", "200"}},
+		{node: "pve1", vmids: []string{"100", "102"}},
+	}
+
+	if got := guestTargets(guests); !reflect.DeepEqual(got, want) {
+		t.Errorf("guestTargets = %+v, want %+v", got, want)
+	}
+
+	if got := guestTargets(nil); len(got) != 0 {
+		t.Errorf("guestTargets(nil) = %+v, want none", got)
+	}
+}
+
+func TestNodeTargets(t *testing.T) {
+	resources := []proxmox.ClusterResource{
+		{Type: "qemu", VMID: 100, Node: "pve3"},
+		{Type: "lxc", VMID: 200, Node: "pve1"},
+		{Type: "qemu", VMID: 101, Node: "pve3"},
+		{Type: "node", Node: "pve2"},
+	}
+
+	want := []backupTarget{{node: "pve1"}, {node: "pve3"}}
+
+	if got := nodeTargets(resources); !reflect.DeepEqual(got, want) {
+		t.Errorf("nodeTargets = %+v, want %+v", got, want)
+	}
+}
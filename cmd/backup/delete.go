@@ -35,13 +35,13 @@ func deleteCmd() *cobra.Command {
 		Use:   "delete",
 		Short: "Delete a backup file from storage",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			if node == "" {
-				node, err = client.DefaultNode()
+				node, err = client.DefaultNode(cmd.Context())
 				if err != nil {
 					return err
 				}
@@ -57,17 +57,24 @@ func deleteCmd() *cobra.Command {
 
 				if confirm != "y" && confirm != "Y" {
 					output.Aborted("Aborted.")
-					return nil
+					return api.ErrAborted
 				}
 			}
 
-			if err := client.Delete(
-				fmt.Sprintf("/nodes/%s/storage/%s/content/%s", node, storage, file),
-			); err != nil {
+			upid, err := client.Storage.DeleteVolume(cmd.Context(), node, storage, file)
+			if err != nil {
+				return err
+			}
+
+			if err := client.Await(cmd.Context(), node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf("Backup '%s' deleted", volid))
+			output.Done(output.Result{
+				Message: fmt.Sprintf("Backup '%s' deleted", volid),
+				UPID:    upid,
+				Node:    node,
+			})
 
 			return nil
 		},
@@ -41,7 +41,7 @@ func createCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create a scheduled backup job",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -74,9 +74,7 @@ func createCmd() *cobra.Command {
 				payload["notes-template"] = notes
 			}
 
-			var resp any
-
-			if err := client.Post("/cluster/backup", payload, &resp); err != nil {
+			if err := client.Cluster.CreateBackupJob(cmd.Context(), payload); err != nil {
 				return err
 			}
 
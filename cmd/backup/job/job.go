@@ -18,6 +18,7 @@ package job
 import (
 	"fmt"
 
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -36,33 +37,18 @@ func NewCommand() *cobra.Command {
 	return cmd
 }
 
-func formatBool(v any) string {
-	if v == nil {
-		return "false"
+func guests(j proxmox.BackupJob) string {
+	if j.All {
+		return "all"
 	}
 
-	switch val := v.(type) {
-	case bool:
-		if val {
-			return "true"
-		}
-
-		return "false"
-	case float64:
-		if val == 1 {
-			return "true"
-		}
-
-		return "false"
-	}
-
-	return "false"
+	return string(j.VMID)
 }
 
-func toString(v any) string {
-	if v == nil {
-		return ""
+func maxFiles(j proxmox.BackupJob) string {
+	if j.MaxFiles > 0 {
+		return fmt.Sprintf("%d", j.MaxFiles)
 	}
 
-	return fmt.Sprintf("%v", v)
+	return j.PruneBackups
 }
@@ -19,7 +19,9 @@ import (
 	"fmt"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -28,44 +30,41 @@ func listCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List all scheduled backup jobs",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data []map[string]any `json:"data"`
+			jobs, err := client.Cluster.BackupJobs(cmd.Context())
+			if err != nil {
+				return err
 			}
 
-			if err := client.Get("/cluster/backup", &resp); err != nil {
-				return err
+			columns := []output.Column[proxmox.BackupJob]{
+				{Header: "JOB ID", Field: "id", Value: func(j proxmox.BackupJob) string { return j.ID }, Key: true},
+				{Header: "VMIDS", Field: "vmid", Value: guests},
+				{Header: "STORAGE", Field: "storage", Value: func(j proxmox.BackupJob) string { return j.Storage }},
+				{Header: "SCHEDULE", Field: "schedule", Value: func(j proxmox.BackupJob) string { return j.Schedule }},
+				{Header: "MODE", Field: "mode", Value: func(j proxmox.BackupJob) string { return j.Mode }},
+				{Header: "ENABLED", Field: "enabled", Value: func(j proxmox.BackupJob) string { return format.Bool(bool(j.Enabled)) }},
+				{Header: "NODE", Field: "node", Value: func(j proxmox.BackupJob) string { return j.Node }, Wide: true},
+				{Header: "NEXT RUN", Field: "next-run", Value: func(j proxmox.BackupJob) string { return format.Epoch(int64(j.NextRun)) }, Wide: true},
+				{Header: "COMMENT", Field: "comment", Value: func(j proxmox.BackupJob) string { return j.Comment }, Wide: true},
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsBare() {
+				return output.List(jobs, columns)
 			}
 
-			if len(resp.Data) == 0 {
+			if len(jobs) == 0 {
 				fmt.Println("No scheduled backup jobs found.")
 				return nil
 			}
 
-			headers := []string{"JOB ID", "VMIDS", "STORAGE", "SCHEDULE", "MODE", "ENABLED"}
-			rows := make([][]string, 0, len(resp.Data))
-
-			for _, j := range resp.Data {
-				rows = append(rows, []string{
-					toString(j["id"]),
-					toString(j["vmid"]),
-					toString(j["storage"]),
-					toString(j["schedule"]),
-					toString(j["mode"]),
-					formatBool(j["enabled"]),
-				})
+			if err := output.List(jobs, columns); err != nil {
+				return err
 			}
 
-			output.Table(headers, rows)
-
 			return nil
 		},
 	}
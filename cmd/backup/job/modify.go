@@ -40,7 +40,7 @@ func modifyCmd() *cobra.Command {
 		Short: "Modify an existing scheduled backup job",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -86,7 +86,7 @@ func modifyCmd() *cobra.Command {
 				return fmt.Errorf("no changes specified")
 			}
 
-			if err := client.Put(fmt.Sprintf("/cluster/backup/%s", args[0]), payload, nil); err != nil {
+			if err := client.Cluster.UpdateBackupJob(cmd.Context(), args[0], payload); err != nil {
 				return err
 			}
 
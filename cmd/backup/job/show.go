@@ -16,9 +16,8 @@ limitations under the License.
 package job
 
 import (
-	"fmt"
-
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -29,37 +28,32 @@ func showCmd() *cobra.Command {
 		Short: "Show details of a scheduled backup job",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data map[string]any `json:"data"`
-			}
-
-			if err := client.Get(fmt.Sprintf("/cluster/backup/%s", args[0]), &resp); err != nil {
+			d, err := client.Cluster.BackupJob(cmd.Context(), args[0])
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsStructured() {
+				return output.Print(d)
 			}
 
-			d := resp.Data
-
 			headers := []string{"FIELD", "VALUE"}
 			rows := [][]string{
-				{"Job ID", toString(d["id"])},
-				{"VM IDs", toString(d["vmid"])},
-				{"Storage", toString(d["storage"])},
-				{"Schedule", toString(d["schedule"])},
-				{"Mode", toString(d["mode"])},
-				{"Compression", toString(d["compress"])},
-				{"Enabled", formatBool(d["enabled"])},
-				{"Mail To", toString(d["mailto"])},
-				{"Max Files", toString(d["maxfiles"])},
-				{"Notes Template", toString(d["notes-template"])},
+				{"Job ID", d.ID},
+				{"VM IDs", guests(*d)},
+				{"Storage", d.Storage},
+				{"Schedule", d.Schedule},
+				{"Mode", d.Mode},
+				{"Compression", d.Compress},
+				{"Enabled", format.Bool(bool(d.Enabled))},
+				{"Mail To", d.MailTo},
+				{"Max Files", maxFiles(*d)},
+				{"Notes Template", d.NotesTemplate},
 			}
 
 			output.Table(headers, rows)
@@ -17,9 +17,12 @@ package backup
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -34,63 +37,66 @@ func listCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List backup files on a storage",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			if node == "" {
-				node, err = client.DefaultNode()
+				node, err = client.DefaultNode(cmd.Context())
 				if err != nil {
 					return err
 				}
 			}
 
-			path := fmt.Sprintf("/nodes/%s/storage/%s/content?content=backup", node, storage)
+			// Backups outlive their guests, so only names need a lookup.
+			if _, name, _ := api.ParseGuestRef(vmid); name != "" {
+				g, err := client.LocateGuest(cmd.Context(), vmid)
+				if err != nil {
+					return err
+				}
 
-			if vmid != "" {
-				path += "&vmid=" + vmid
+				vmid = strconv.Itoa(g.VMID)
 			}
 
-			var resp struct {
-				Data []map[string]any `json:"data"`
+			backups, err := client.Storage.Content(cmd.Context(), node, storage, proxmox.ContentOptions{
+				Content: "backup",
+				VMID:    vmid,
+			})
+			if err != nil {
+				return err
 			}
 
-			if err := client.Get(path, &resp); err != nil {
-				return err
+			columns := []output.Column[proxmox.StorageContent]{
+				{Header: "VOLID", Field: "volid", Value: func(b proxmox.StorageContent) string { return b.VolID }, Key: true},
+				{Header: "VMID", Field: "vmid", Value: func(b proxmox.StorageContent) string { return format.VMID(int64(b.VMID)) }},
+				{Header: "FORMAT", Field: "format", Value: func(b proxmox.StorageContent) string { return b.Format }},
+				{Header: "SIZE", Field: "size", Value: func(b proxmox.StorageContent) string { return output.Bytes(float64(b.Size)) }},
+				{Header: "CREATED", Field: "ctime", Value: func(b proxmox.StorageContent) string { return format.Epoch(int64(b.CTime)) }},
+				{Header: "PROTECTED", Field: "protected", Value: func(b proxmox.StorageContent) string { return format.Bool(bool(b.Protected)) }, Wide: true},
+				{Header: "NOTES", Field: "notes", Value: func(b proxmox.StorageContent) string { return b.Notes }, Wide: true},
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsBare() {
+				return output.List(backups, columns)
 			}
 
-			if len(resp.Data) == 0 {
+			if len(backups) == 0 {
 				fmt.Printf("No backups found on storage '%s'.\n", storage)
 				return nil
 			}
 
-			headers := []string{"VOLID", "VMID", "FORMAT", "SIZE", "CREATED"}
-			rows := make([][]string, 0, len(resp.Data))
-
-			for _, b := range resp.Data {
-				rows = append(rows, []string{
-					toString(b["volid"]),
-					fmt.Sprintf("%.0f", toFloat(b["vmid"])),
-					toString(b["format"]),
-					formatBytes(toFloat(b["size"])),
-					formatEpoch(b["ctime"]),
-				})
+			if err := output.List(backups, columns); err != nil {
+				return err
 			}
 
-			output.Table(headers, rows)
-
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&node, "node", "", "Proxmox node (auto-detected if not set)")
 	cmd.Flags().StringVar(&storage, "storage", "", "Storage to list backups from (required)")
-	cmd.Flags().StringVar(&vmid, "vmid", "", "Filter by VM/container ID")
+	cmd.Flags().StringVar(&vmid, "vmid", "", "Filter by VM/container ID or name")
 
 	_ = cmd.MarkFlagRequired("storage")
 
@@ -16,7 +16,9 @@ limitations under the License.
 package backup
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
@@ -40,14 +42,25 @@ func restoreCmd() *cobra.Command {
 		Use:   "restore",
 		Short: "Restore a VM or container from a backup",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
+				// Overwriting an existing guest must happen on its owning node;
+				// a brand-new VMID can be restored anywhere.
+				guest, err := client.ResolveGuest(cmd.Context(), strconv.Itoa(vmid), "", "")
+
+				switch {
+				case err == nil:
+					node = guest.Node
+				case errors.Is(err, api.ErrGuestNotFound):
+					node, err = client.DefaultNode(cmd.Context())
+					if err != nil {
+						return err
+					}
+				default:
 					return err
 				}
 			}
@@ -62,7 +75,7 @@ func restoreCmd() *cobra.Command {
 
 				if confirm != "y" && confirm != "Y" {
 					output.Aborted("Aborted.")
-					return nil
+					return api.ErrAborted
 				}
 			}
 
@@ -80,25 +93,31 @@ func restoreCmd() *cobra.Command {
 				payload["start"] = 1
 			}
 
-			var apiPath string
+			service := client.Qemu
 
 			switch strings.ToLower(gtype) {
 			case "lxc", "ct":
-				apiPath = fmt.Sprintf("/nodes/%s/lxc", node)
-			default:
-				apiPath = fmt.Sprintf("/nodes/%s/qemu", node)
+				service = client.LXC
 			}
 
-			var resp any
+			upid, err := service.Create(cmd.Context(), node, payload)
+			if err != nil {
+				return err
+			}
 
-			if err := client.Post(apiPath, payload, &resp); err != nil {
+			if err := client.Await(cmd.Context(), node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf(
-				"Restore of %s %d from '%s' task queued on node %s",
-				gtype, vmid, archive, node,
-			))
+			output.Done(output.Result{
+				Message: fmt.Sprintf(
+					"Restore of %s %d from '%s' task %s on node %s",
+					gtype, vmid, archive, api.TaskState(), node,
+				),
+				UPID: upid,
+				Node: node,
+				VMID: vmid,
+			})
 
 			return nil
 		},
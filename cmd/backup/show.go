@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -34,13 +35,13 @@ func showCmd() *cobra.Command {
 		Use:   "show",
 		Short: "Show details of a specific backup file",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			if node == "" {
-				node, err = client.DefaultNode()
+				node, err = client.DefaultNode(cmd.Context())
 				if err != nil {
 					return err
 				}
@@ -48,32 +49,24 @@ func showCmd() *cobra.Command {
 
 			volid := fmt.Sprintf("%s:%s", storage, file)
 
-			var resp struct {
-				Data map[string]any `json:"data"`
-			}
-
-			if err := client.Get(
-				fmt.Sprintf("/nodes/%s/storage/%s/content/%s", node, storage, file),
-				&resp,
-			); err != nil {
+			d, err := client.Storage.Volume(cmd.Context(), node, storage, file)
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsStructured() {
+				return output.Print(d)
 			}
 
-			d := resp.Data
-
 			headers := []string{"FIELD", "VALUE"}
 			rows := [][]string{
 				{"Volume ID", volid},
-				{"Format", toString(d["format"])},
-				{"Size", formatBytes(toFloat(d["size"]))},
-				{"Created", formatEpoch(d["ctime"])},
-				{"Notes", toString(d["notes"])},
-				{"Protected", formatBool(d["protected"])},
-				{"Encrypted", formatBool(d["encrypted"])},
+				{"Format", d.Format},
+				{"Size", output.Bytes(float64(d.Size))},
+				{"Created", format.Epoch(int64(d.CTime))},
+				{"Notes", d.Notes},
+				{"Protected", format.Bool(bool(d.Protected))},
+				{"Encrypted", format.Bool(d.Encrypted != "" && d.Encrypted != "0")},
 			}
 
 			output.Table(headers, rows)
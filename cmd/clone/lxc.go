@@ -34,20 +34,18 @@ func lxcCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "lxc <vmid>",
+		Use:   "lxc <vmid|name>",
 		Short: "Clone an LXC container",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
-				}
+			guest, err := client.ResolveGuest(cmd.Context(), args[0], node, "lxc")
+			if err != nil {
+				return err
 			}
 
 			payload := map[string]any{
@@ -70,18 +68,24 @@ func lxcCmd() *cobra.Command {
 				payload["storage"] = storage
 			}
 
-			var resp any
-
-			path := fmt.Sprintf("/nodes/%s/lxc/%s/clone", node, args[0])
+			upid, err := client.LXC.Clone(cmd.Context(), guest.Node, guest.VMID, payload)
+			if err != nil {
+				return err
+			}
 
-			if err := client.Post(path, payload, &resp); err != nil {
+			if err := client.Await(cmd.Context(), guest.Node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf(
-				"Clone of LXC container %s → container %d queued on node %s",
-				args[0], newid, node,
-			))
+			output.Done(output.Result{
+				Message: fmt.Sprintf(
+					"Clone of LXC container %d → container %d %s on node %s",
+					guest.VMID, newid, api.TaskState(), guest.Node,
+				),
+				UPID: upid,
+				Node: guest.Node,
+				VMID: newid,
+			})
 
 			return nil
 		},
@@ -36,20 +36,18 @@ func vmCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "vm <vmid>",
+		Use:   "vm <vmid|name>",
 		Short: "Clone a KVM VM",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
-				}
+			guest, err := client.ResolveGuest(cmd.Context(), args[0], node, "qemu")
+			if err != nil {
+				return err
 			}
 
 			if newid == 0 {
@@ -86,11 +84,12 @@ func vmCmd() *cobra.Command {
 				payload["full"] = 1
 			}
 
-			var resp any
-
-			path := fmt.Sprintf("/nodes/%s/qemu/%s/clone", node, args[0])
+			upid, err := client.Qemu.Clone(cmd.Context(), guest.Node, guest.VMID, payload)
+			if err != nil {
+				return err
+			}
 
-			if err := client.Post(path, payload, &resp); err != nil {
+			if err := client.Await(cmd.Context(), guest.Node, upid); err != nil {
 				return err
 			}
 
@@ -100,10 +99,15 @@ func vmCmd() *cobra.Command {
 				cloneType = "linked"
 			}
 
-			output.Success(fmt.Sprintf(
-				"%s clone of VM %s → VM %d queued on node %s",
-				cloneType, args[0], newid, node,
-			))
+			output.Done(output.Result{
+				Message: fmt.Sprintf(
+					"%s clone of VM %d → VM %d %s on node %s",
+					cloneType, guest.VMID, newid, api.TaskState(), guest.Node,
+				),
+				UPID: upid,
+				Node: guest.Node,
+				VMID: newid,
+			})
 
 			return nil
 		},
@@ -0,0 +1,158 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/prompt"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+func addContextCmd() *cobra.Command {
+	var (
+		serverName string
+		serverURL  string
+		apiToken   string
+		tokenFile  string
+		tokenCmd   string
+		username   string
+		caFile     string
+		insecure   bool
+		use        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add-context <name>",
+		Short: "Add a named context for another cluster",
+		Long: `Add a named context holding the connection settings for one cluster.
+
+The API token is prompted for when no token source and no --username is
+given; leave it empty to log in with 'proxmoxctl --context <name> login'
+instead. An existing context with the same name is replaced.
+
+Examples:
+  proxmoxctl config add-context lab --server-url https://10.0.0.5:8006
+  proxmoxctl config add-context prod --server-url https://pve.example.com:8006 \
+    --username admin@pve --use`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := settings.ValidateContextName(name); err != nil {
+				return err
+			}
+
+			flags := []struct {
+				key   string
+				value *string
+			}{
+				{settings.KeyServerURL, &serverURL},
+				{settings.KeyAPIToken, &apiToken},
+				{settings.KeyAPITokenFile, &tokenFile},
+				{settings.KeyAPITokenCommand, &tokenCmd},
+				{settings.KeyUsername, &username},
+				{api.KeyTLSCAFile, &caFile},
+			}
+
+			for _, f := range flags {
+				if err := validate(f.key, f.value); err != nil {
+					return err
+				}
+			}
+
+			reader := bufio.NewReader(os.Stdin)
+
+			pin, trusted := trustCertificate(cmd.Context(), reader, serverURL, caFile, "")
+			if !trusted && !insecure {
+				output.Aborted("Certificate not trusted; context not added. Pass --tls-ca-file with your CA bundle.")
+				return api.ErrAborted
+			}
+
+			if apiToken == "" && tokenFile == "" && tokenCmd == "" && username == "" {
+				apiToken = prompt.Secret(reader, "API Token (USER@REALM!TOKENID=SECRET, empty to use login): ")
+
+				if err := validate(settings.KeyAPIToken, &apiToken); err != nil {
+					return err
+				}
+			}
+
+			ctx := map[string]any{
+				settings.KeyServerURL: serverURL,
+			}
+
+			for key, value := range map[string]string{
+				settings.KeyServerName:      serverName,
+				settings.KeyAPIToken:        apiToken,
+				settings.KeyAPITokenFile:    tokenFile,
+				settings.KeyAPITokenCommand: tokenCmd,
+				settings.KeyUsername:        username,
+				api.KeyTLSCAFile:            caFile,
+				api.KeyTLSFingerprint:       pin,
+			} {
+				if value != "" {
+					ctx[key] = value
+				}
+			}
+
+			if insecure {
+				ctx[api.KeyInsecureSkipVerify] = true
+			}
+
+			err := settings.Update(func(cfg map[string]any) error {
+				// The first cluster configured becomes current; after that
+				// only --use switches away from what is in use.
+				_, flat := cfg[settings.KeyServerURL]
+				_, contexts := cfg[settings.KeyContexts]
+
+				settings.SetKey(cfg, settings.KeyContexts+"."+name, ctx)
+
+				if use || (!flat && !contexts) {
+					settings.SetKey(cfg, settings.KeyCurrentContext, name)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("Context '%s' added", name))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&serverName, "server-name", "", "Friendly label for the cluster")
+	cmd.Flags().StringVar(&serverURL, "server-url", "", "Server URL, e.g. https://192.168.1.10:8006 (required)")
+	cmd.Flags().StringVar(&apiToken, "api-token", "", "API token (USER@REALM!TOKENID=SECRET); prompted if not provided")
+	cmd.Flags().StringVar(&tokenFile, "api-token-file", "", "Read the API token from this file (must be mode 0600)")
+	cmd.Flags().StringVar(&tokenCmd, "api-token-command", "", "Run this command to print the API token")
+	cmd.Flags().StringVar(&username, "username", "", "Default user for 'proxmoxctl login' in this context")
+	cmd.Flags().StringVar(&caFile, "tls-ca-file", "", "PEM bundle of the private CA that signed the server certificate")
+	cmd.Flags().BoolVar(&insecure, "tls-insecure", false, "Disable TLS certificate verification for this context (not recommended)")
+	cmd.Flags().BoolVar(&use, "use", false, "Make this the current context")
+
+	cmd.MarkFlagsMutuallyExclusive("api-token", "api-token-file", "api-token-command")
+
+	_ = cmd.MarkFlagRequired("server-url")
+
+	return cmd
+}
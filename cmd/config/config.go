@@ -25,8 +25,15 @@ func NewCommand() *cobra.Command {
 		Short: "Manage proxmoxctl configuration",
 	}
 
+	cmd.AddCommand(addContextCmd())
+	cmd.AddCommand(deleteContextCmd())
+	cmd.AddCommand(getCmd())
+	cmd.AddCommand(getContextsCmd())
 	cmd.AddCommand(setCmd())
 	cmd.AddCommand(showCmd())
+	cmd.AddCommand(unsetCmd())
+	cmd.AddCommand(useContextCmd())
+	cmd.AddCommand(viewCmd())
 
 	return cmd
 }
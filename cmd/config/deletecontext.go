@@ -0,0 +1,75 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+func deleteContextCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "delete-context <name>",
+		Short: "Delete a named context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !slices.Contains(settings.Contexts(), name) {
+				return fmt.Errorf("%w: %q — see `proxmoxctl config get-contexts`", settings.ErrNoContext, name)
+			}
+
+			if !force {
+				var confirm string
+
+				fmt.Printf("Delete context '%s'? [y/N]: ", name)
+				_, _ = fmt.Scanln(&confirm)
+
+				if confirm != "y" && confirm != "Y" {
+					output.Aborted("Aborted.")
+					return api.ErrAborted
+				}
+			}
+
+			err := settings.Update(func(cfg map[string]any) error {
+				settings.UnsetKey(cfg, settings.KeyContexts+"."+name)
+
+				if current, _ := cfg[settings.KeyCurrentContext].(string); current == name {
+					settings.UnsetKey(cfg, settings.KeyCurrentContext)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("Context '%s' deleted", name))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+
+	return cmd
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func getCmd() *cobra.Command {
+	var reveal bool
+
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a config key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := lookupKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			if reveal {
+				k.Secret = false
+			}
+
+			fmt.Println(k.value())
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&reveal, "reveal", false, "Print secrets such as api_token unmasked")
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type contextInfo struct {
+	Name      string `json:"name"`
+	Current   bool   `json:"current"`
+	ServerURL string `json:"server_url"`
+	Auth      string `json:"auth"`
+}
+
+func getContextsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the named contexts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current := settings.CurrentContext()
+			contexts := make([]contextInfo, 0)
+
+			for _, name := range settings.Contexts() {
+				sub := viper.Sub(settings.KeyContexts + "." + name)
+				if sub == nil {
+					continue
+				}
+
+				auth := "login"
+				if sub.GetString(settings.KeyAPIToken) != "" {
+					auth = "api token"
+				} else if user := sub.GetString(settings.KeyUsername); user != "" {
+					auth = "login (" + user + ")"
+				}
+
+				contexts = append(contexts, contextInfo{
+					Name:      name,
+					Current:   name == current,
+					ServerURL: sub.GetString(settings.KeyServerURL),
+					Auth:      auth,
+				})
+			}
+
+			columns := []output.Column[contextInfo]{
+				{Header: "CURRENT", Field: "current", Value: func(c contextInfo) string {
+					if c.Current {
+						return "*"
+					}
+
+					return ""
+				}},
+				{Header: "NAME", Field: "name", Value: func(c contextInfo) string { return c.Name }, Key: true},
+				{Header: "SERVER", Field: "server_url", Value: func(c contextInfo) string { return c.ServerURL }},
+				{Header: "AUTH", Field: "auth", Value: func(c contextInfo) string { return c.Auth }},
+			}
+
+			if !output.IsBare() && len(contexts) == 0 {
+				fmt.Println("No contexts configured. Add one with `proxmoxctl config add-context`.")
+				return nil
+			}
+
+			return output.List(contexts, columns)
+		},
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configKey describes a setting that `config set`, `get`, `unset`, and
+// `view` understand.
+type configKey struct {
+	Name   string
+	Flag   string // persistent flag bound to the key, if any
+	Env    string // environment variable, when not PROXMOX_<NAME>
+	Secret bool
+	Parse  func(string) (any, error)
+}
+
+var apiTokenFormat = regexp.MustCompile(`^[^@\s]+@[^!\s]+![^=\s]+=\S+$`)
+
+var configKeys = []configKey{
+	{Name: settings.KeyServerName, Parse: parseString},
+	{Name: settings.KeyServerURL, Parse: parseURL},
+	{Name: settings.KeyAPIToken, Secret: true, Parse: parseToken},
+	{Name: settings.KeyAPITokenFile, Parse: parseFile},
+	{Name: settings.KeyAPITokenCommand, Parse: parseString},
+	{Name: settings.KeyUsername, Parse: parseUsername},
+	{Name: settings.KeyDefaultNode, Parse: parseString},
+	{Name: api.KeyInsecureSkipVerify, Flag: "insecure", Parse: parseBool},
+	{Name: api.KeyTLSFingerprint, Parse: parseFingerprint},
+	{Name: api.KeyTLSCAFile, Parse: parseFile},
+	{Name: output.KeyOutputFormat, Flag: "output", Parse: parseEnum("table", "wide", "json", "yaml", "csv", "tsv")},
+	{Name: output.KeyNoHeaders, Flag: "no-headers", Parse: parseBool},
+	{Name: color.KeyNoColor, Flag: "no-color", Parse: parseBool},
+	{Name: api.KeyRequestTimeout, Flag: "request-timeout", Parse: parseDuration},
+	{Name: api.KeyWait, Flag: "wait", Parse: parseBool},
+	{Name: api.KeyWaitTimeout, Flag: "timeout", Parse: parseDuration},
+	{Name: api.KeyRetryAttempts, Parse: parseCount},
+	{Name: api.KeyRetryDelay, Parse: parseDuration},
+	{Name: api.KeyRetryMaxDelay, Parse: parseDuration},
+	{Name: api.KeyVerbose, Flag: "verbose", Parse: parseBool},
+	{Name: settings.KeyCurrentContext, Flag: "context", Env: "PROXMOX_CONTEXT", Parse: parseContext},
+}
+
+func lookupKey(name string) (configKey, error) {
+	name = strings.ToLower(name)
+
+	for _, k := range configKeys {
+		if k.Name == name {
+			return k, nil
+		}
+	}
+
+	names := make([]string, 0, len(configKeys))
+	for _, k := range configKeys {
+		names = append(names, k.Name)
+	}
+
+	return configKey{}, fmt.Errorf("unknown config key %q — known keys: %s", name, strings.Join(names, ", "))
+}
+
+// target returns where key is stored: inside the active context for the
+// settings that describe a cluster, at the top of the file otherwise.
+func (k configKey) target() string {
+	if settings.IsConnectionKey(k.Name) {
+		return settings.ContextKey(settings.CurrentContext(), k.Name)
+	}
+
+	return k.Name
+}
+
+// value returns the effective value of the key as text.
+func (k configKey) value() string {
+	if k.Name == settings.KeyCurrentContext {
+		return settings.CurrentContext()
+	}
+
+	v := viper.Get(k.Name)
+	if v == nil {
+		return ""
+	}
+
+	s := fmt.Sprint(v)
+	if k.Secret && s != "" {
+		return mask(s)
+	}
+
+	return s
+}
+
+// source names the layer the effective value comes from, in precedence
+// order: flag, environment, context, config file, default.
+func (k configKey) source(cmd *cobra.Command) string {
+	if k.Flag != "" {
+		if f := cmd.Root().PersistentFlags().Lookup(k.Flag); f != nil && f.Changed {
+			return "flag --" + k.Flag
+		}
+	}
+
+	env := k.Env
+	if env == "" {
+		env = "PROXMOX_" + strings.ToUpper(k.Name)
+	}
+
+	if _, ok := os.LookupEnv(env); ok {
+		return "env " + env
+	}
+
+	if name := settings.CurrentContext(); name != "" {
+		if viper.IsSet(settings.ContextKey(name, k.Name)) {
+			return "context " + name
+		}
+
+	}
+
+	// A context does not inherit connection settings from the top level.
+	inherited := settings.CurrentContext() == "" || !settings.IsConnectionKey(k.Name)
+
+	if inherited && viper.InConfig(k.Name) {
+		return "config"
+	}
+
+	if k.value() != "" {
+		return "default"
+	}
+
+	return ""
+}
+
+// mask hides a secret except for its first and last four characters.
+func mask(value string) string {
+	if len(value) > 8 {
+		return value[:4] + "****" + value[len(value)-4:]
+	}
+
+	return "****"
+}
+
+func parseString(s string) (any, error) {
+	return s, nil
+}
+
+func parseURL(s string) (any, error) {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("%q is not a server URL — expected e.g. https://192.168.1.10:8006", s)
+	}
+
+	return strings.TrimRight(s, "/"), nil
+}
+
+func parseToken(s string) (any, error) {
+	if !apiTokenFormat.MatchString(s) {
+		return nil, fmt.Errorf("API token must have the form USER@REALM!TOKENID=SECRET")
+	}
+
+	return s, nil
+}
+
+func parseUsername(s string) (any, error) {
+	if !strings.Contains(s, "@") {
+		return nil, fmt.Errorf("username must include the realm, e.g. root@pam")
+	}
+
+	return s, nil
+}
+
+func parseFile(s string) (any, error) {
+	path, err := settings.ExpandHome(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func parseBool(s string) (any, error) {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a boolean — use true or false", s)
+	}
+
+	return b, nil
+}
+
+func parseDuration(s string) (any, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return nil, fmt.Errorf("%q is not a duration — use e.g. 30s, 2m, or 0", s)
+	}
+
+	return d.String(), nil
+}
+
+func parseCount(s string) (any, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("%q is not a non-negative number", s)
+	}
+
+	return n, nil
+}
+
+func parseFingerprint(s string) (any, error) {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, ":", ""))
+	if err != nil || len(b) != 32 {
+		return nil, fmt.Errorf("%q is not a SHA-256 fingerprint (AB:CD:...)", s)
+	}
+
+	return strings.ToUpper(s), nil
+}
+
+func parseContext(s string) (any, error) {
+	if !slices.Contains(settings.Contexts(), s) {
+		return nil, fmt.Errorf("%w: %q — see `proxmoxctl config get-contexts`", settings.ErrNoContext, s)
+	}
+
+	return s, nil
+}
+
+func parseEnum(values ...string) func(string) (any, error) {
+	return func(s string) (any, error) {
+		if !slices.Contains(values, strings.ToLower(s)) {
+			return nil, fmt.Errorf("%q is not one of: %s", s, strings.Join(values, ", "))
+		}
+
+		return strings.ToLower(s), nil
+	}
+}
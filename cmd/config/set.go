@@ -19,22 +19,63 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
-	"syscall"
 
+	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/prompt"
 	"github.com/dcjulian29/proxmoxctl/internal/settings"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"golang.org/x/term"
 )
 
 func setCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "set",
-		Short: "Interactively set server connection settings",
+	var (
+		tokenFile    string
+		tokenCommand string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set [<key> <value>]",
+		Short: "Set connection settings interactively, or a single key",
+		Long: `Set the server connection settings interactively, or set a single key
+without prompting when <key> and <value> are given. Values are validated
+before they are saved; see 'proxmoxctl config view' for the known keys.
+
+The settings are saved to the active context (see --context and 'config
+use-context'), or to the top of the config file when no context is in use.
+
+To keep the API token out of config.yml, store a reference instead:
+--api-token-file names a file only you can read (mode 0600), and
+--api-token-command names a command that prints the token, such as a
+password manager.
+
+Examples:
+  proxmoxctl config set
+  proxmoxctl config set server_url https://pve.example.com:8006
+  proxmoxctl config set output_format json
+  proxmoxctl config set --api-token-file ~/.config/proxmoxctl/token
+  proxmoxctl config set --api-token-command "pass show pve/token"`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 && len(args) != 2 {
+				return fmt.Errorf("expected no arguments or <key> <value>, got %d", len(args))
+			}
+
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 2 {
+				return setKey(args[0], args[1])
+			}
+
 			reader := bufio.NewReader(os.Stdin)
+			name := settings.CurrentContext()
+
+			if name != "" {
+				fmt.Println(color.Teal("Configuring context: " + name))
+			}
 
 			fmt.Print(color.Green("Server name (friendly label): "))
 			serverName, _ := reader.ReadString('\n')
@@ -44,28 +85,107 @@ func setCmd() *cobra.Command {
 			serverURL, _ := reader.ReadString('\n')
 			serverURL = strings.TrimSpace(serverURL)
 
-			fmt.Print(color.Green("API Token (USER@REALM!TOKENID=SECRET): "))
-			tokenBytes, err := term.ReadPassword(int(syscall.Stdin))
-
-			if err != nil {
-				// Fallback for non-TTY environments
-				apiToken, _ := reader.ReadString('\n')
-				tokenBytes = []byte(strings.TrimSpace(apiToken))
+			if err := validate(settings.KeyServerURL, &serverURL); err != nil {
+				return err
 			}
 
-			fmt.Println()
+			pin, trusted := trustCertificate(cmd.Context(), reader, serverURL,
+				viper.GetString(api.KeyTLSCAFile), viper.GetString(api.KeyTLSFingerprint))
 
-			apiToken := strings.TrimSpace(string(tokenBytes))
+			if !trusted {
+				output.Aborted(fmt.Sprintf("Certificate not trusted; configuration not saved. "+
+					"Set %s to your CA bundle or re-run `proxmoxctl config set`.", api.KeyTLSCAFile))
+				return api.ErrAborted
+			}
 
-			viper.Set(settings.KeyServerName, serverName)
-			viper.Set(settings.KeyServerURL, serverURL)
-			viper.Set(settings.KeyAPIToken, apiToken)
+			// Exactly one token source is kept; the others are removed.
+			source, value := settings.KeyAPIToken, ""
 
-			if err := settings.Save(); err != nil {
-				return fmt.Errorf("saving config: %w", err)
+			switch {
+			case tokenFile != "":
+				source, value = settings.KeyAPITokenFile, tokenFile
+			case tokenCommand != "":
+				source, value = settings.KeyAPITokenCommand, tokenCommand
+			default:
+				value = prompt.Secret(reader, "API Token (USER@REALM!TOKENID=SECRET): ")
 			}
 
-			return nil
+			if err := validate(source, &value); err != nil {
+				return err
+			}
+
+			return settings.Update(func(cfg map[string]any) error {
+				settings.SetKey(cfg, settings.ContextKey(name, settings.KeyServerName), serverName)
+				settings.SetKey(cfg, settings.ContextKey(name, settings.KeyServerURL), serverURL)
+
+				for _, key := range settings.TokenKeys {
+					settings.UnsetKey(cfg, settings.ContextKey(name, key))
+				}
+
+				settings.SetKey(cfg, settings.ContextKey(name, source), value)
+
+				if pin != "" {
+					settings.SetKey(cfg, settings.ContextKey(name, api.KeyTLSFingerprint), pin)
+				} else {
+					settings.UnsetKey(cfg, settings.ContextKey(name, api.KeyTLSFingerprint))
+				}
+
+				return nil
+			})
 		},
 	}
+
+	cmd.Flags().StringVar(&tokenFile, "api-token-file", "", "Read the API token from this file (must be mode 0600)")
+	cmd.Flags().StringVar(&tokenCommand, "api-token-command", "", "Run this command to print the API token")
+	cmd.MarkFlagsMutuallyExclusive("api-token-file", "api-token-command")
+
+	return cmd
+}
+
+func setKey(name, raw string) error {
+	k, err := lookupKey(name)
+	if err != nil {
+		return err
+	}
+
+	value, err := k.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", k.Name, err)
+	}
+
+	return settings.Update(func(cfg map[string]any) error {
+		// Setting one token source drops the others, which would win or
+		// leave a secret behind.
+		if slices.Contains(settings.TokenKeys, k.Name) {
+			for _, key := range settings.TokenKeys {
+				settings.UnsetKey(cfg, settings.ContextKey(settings.CurrentContext(), key))
+			}
+		}
+
+		settings.SetKey(cfg, k.target(), value)
+
+		return nil
+	})
+}
+
+// validate checks a value for key the way 'config set <key> <value>' does,
+// replacing it with the normalized form. Empty values are left alone.
+func validate(key string, value *string) error {
+	if *value == "" {
+		return nil
+	}
+
+	k, err := lookupKey(key)
+	if err != nil {
+		return err
+	}
+
+	v, err := k.Parse(*value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", k.Name, err)
+	}
+
+	*value = fmt.Sprint(v)
+
+	return nil
 }
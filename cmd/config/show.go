@@ -16,8 +16,10 @@ limitations under the License.
 package config
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/settings"
 
 	"github.com/spf13/cobra"
@@ -33,19 +35,39 @@ func showCmd() *cobra.Command {
 			serverURL := viper.GetString(settings.KeyServerURL)
 			token := viper.GetString(settings.KeyAPIToken)
 
+			if err := settings.RequireConfig(); errors.Is(err, settings.ErrNoContext) {
+				return err
+			}
+
 			if serverURL == "" {
 				err := fmt.Errorf("no configuration found. Run `proxmoxctl config set` to get started")
 				return err
 			}
 
-			masked := "****"
-			if len(token) > 8 {
-				masked = token[:4] + "****" + token[len(token)-4:]
+			if name := settings.CurrentContext(); name != "" {
+				fmt.Printf("Context     : %s\n", name)
 			}
 
 			fmt.Printf("Server Name : %s\n", serverName)
 			fmt.Printf("Server URL  : %s\n", serverURL)
-			fmt.Printf("API Token   : %s\n", masked)
+			// Token helpers are not run here; only where the token comes from is shown.
+			switch source := api.TokenSource(); source {
+			case "":
+				fmt.Printf("API Token   : (none — sessions from `proxmoxctl login` are used)\n")
+			case settings.KeyAPIToken, "PROXMOX_API_TOKEN":
+				fmt.Printf("API Token   : %s\n", mask(token))
+				fmt.Printf("Token From  : %s\n", source)
+			default:
+				fmt.Printf("Token From  : %s\n", source)
+			}
+
+			if fp := viper.GetString(api.KeyTLSFingerprint); fp != "" {
+				fmt.Printf("TLS Pin     : %s\n", fp)
+			}
+
+			if ca := viper.GetString(api.KeyTLSCAFile); ca != "" {
+				fmt.Printf("TLS CA File : %s\n", ca)
+			}
 
 			return nil
 		},
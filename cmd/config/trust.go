@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+)
+
+// probeTimeout bounds the certificate check so an unreachable server does
+// not hang `config set`.
+const probeTimeout = 10 * time.Second
+
+// trustCertificate shows the fingerprint of a certificate that no trusted CA
+// signed and asks whether to pin it, the way SSH asks about an unknown host
+// key. It returns the fingerprint to store ("" when a CA vouches for the
+// server) and false when the user declines.
+func trustCertificate(ctx context.Context, reader *bufio.Reader, serverURL, caFile, pinned string) (string, bool) {
+	if !strings.HasPrefix(strings.ToLower(serverURL), "https://") {
+		return pinned, true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	cert, err := api.ProbeCertificate(ctx, serverURL, caFile)
+	if err != nil {
+		fmt.Println(color.Yellow(fmt.Sprintf("Could not check the server certificate: %v", err)))
+		return pinned, true
+	}
+
+	if cert.Trusted {
+		return "", true
+	}
+
+	if cert.Matches(pinned) {
+		return pinned, true
+	}
+
+	if pinned != "" {
+		fmt.Println(color.Red("WARNING: the server certificate has changed since it was trusted!"))
+		fmt.Printf("  Pinned fingerprint : %s\n", pinned)
+	} else {
+		fmt.Println(color.Yellow("The server certificate is not signed by a trusted CA."))
+	}
+
+	fmt.Printf("  Subject            : %s\n", cert.Subject)
+	fmt.Printf("  Issuer             : %s\n", cert.Issuer)
+	fmt.Printf("  SHA-256 fingerprint: %s\n", cert.Fingerprint)
+	fmt.Print(color.Green("Trust this certificate? [y/N]: "))
+
+	confirm, _ := reader.ReadString('\n')
+	if c := strings.TrimSpace(confirm); c != "y" && c != "Y" {
+		return "", false
+	}
+
+	return cert.Fingerprint, true
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+func unsetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a key from the config file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := lookupKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			return settings.Update(func(cfg map[string]any) error {
+				if !settings.UnsetKey(cfg, k.target()) {
+					return fmt.Errorf("%s is not set in the config file", k.target())
+				}
+
+				return nil
+			})
+		},
+	}
+}
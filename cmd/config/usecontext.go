@@ -0,0 +1,51 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+func useContextCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-context <name>",
+		Short: "Switch the current context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !slices.Contains(settings.Contexts(), name) {
+				return fmt.Errorf("%w: %q — see `proxmoxctl config get-contexts`", settings.ErrNoContext, name)
+			}
+
+			err := settings.Update(func(cfg map[string]any) error {
+				settings.SetKey(cfg, settings.KeyCurrentContext, name)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("Switched to context '%s'", name))
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type viewEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+func viewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "view",
+		Short: "Show the effective configuration and where each value comes from",
+		Long: `Show the effective value of every known key after merging flags,
+environment variables, the active context, the config file, and defaults,
+along with the layer each value came from. Secrets are masked.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries := make([]viewEntry, 0, len(configKeys))
+
+			for _, k := range configKeys {
+				entries = append(entries, viewEntry{
+					Key:    k.Name,
+					Value:  k.value(),
+					Source: k.source(cmd),
+				})
+			}
+
+			return output.List(entries, []output.Column[viewEntry]{
+				{Header: "KEY", Field: "key", Value: func(e viewEntry) string { return e.Key }, Key: true},
+				{Header: "VALUE", Field: "value", Value: func(e viewEntry) string { return e.Value }},
+				{Header: "SOURCE", Field: "source", Value: func(e viewEntry) string {
+					if e.Source == "" {
+						return "(unset)"
+					}
+
+					return e.Source
+				}},
+			})
+		},
+	}
+}
@@ -0,0 +1,503 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	statusPass = "pass"
+	statusWarn = "warn"
+	statusFail = "fail"
+	statusSkip = "skip"
+)
+
+const (
+	// dialTimeout bounds the TCP and TLS checks.
+	dialTimeout = 5 * time.Second
+
+	// certExpiryWarning is how early an expiring certificate is flagged.
+	certExpiryWarning = 30 * 24 * time.Hour
+
+	// Tickets and TOTP codes tolerate little clock drift.
+	warnClockSkew = 5 * time.Second
+	failClockSkew = 30 * time.Second
+)
+
+// auditPrivileges are what the read-only commands need.
+var auditPrivileges = []string{"Sys.Audit", "VM.Audit", "Datastore.Audit"}
+
+type check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+type report struct {
+	OK      bool    `json:"ok"`
+	Server  string  `json:"server"`
+	Context string  `json:"context,omitempty"`
+	Checks  []check `json:"checks"`
+}
+
+func NewCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose connectivity, TLS, credentials, and privileges",
+		Long: `Check the connection to the configured server step by step and report
+what is wrong with a hint for fixing it:
+
+  config      the server URL parses
+  tcp         the host resolves and the port accepts connections
+  tls         the certificate is trusted or pinned, and not about to expire
+  api         pveproxy answers /version
+  auth        the API token or login session is accepted
+  privileges  the effective privileges include the audit privileges
+  clock       node clocks agree with this machine's
+  quorum      the cluster is quorate and all nodes are online
+
+A failure in the first five checks skips the rest. The command exits
+non-zero when any check fails; use -o json for CI.
+
+Examples:
+  proxmoxctl doctor
+  proxmoxctl doctor --context lab -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r := run(cmd.Context())
+
+			if output.IsStructured() {
+				if err := output.Print(r); err != nil {
+					return err
+				}
+			} else {
+				printReport(r)
+			}
+
+			if !r.OK {
+				return api.ExitStatus(api.ExitError)
+			}
+
+			return nil
+		},
+	}
+}
+
+// doctor carries what earlier checks learned to the later ones.
+type doctor struct {
+	ctx    context.Context
+	url    *url.URL
+	client *api.Client
+}
+
+func run(ctx context.Context) report {
+	d := &doctor{ctx: ctx}
+
+	steps := []struct {
+		name  string
+		fatal bool
+		fn    func() check
+	}{
+		{"config", true, d.checkConfig},
+		{"tcp", true, d.checkTCP},
+		{"tls", true, d.checkTLS},
+		{"api", true, d.checkAPI},
+		{"auth", true, d.checkAuth},
+		{"privileges", false, d.checkPrivileges},
+		{"clock", false, d.checkClock},
+		{"quorum", false, d.checkQuorum},
+	}
+
+	r := report{
+		OK:      true,
+		Server:  viper.GetString(settings.KeyServerURL),
+		Context: settings.CurrentContext(),
+	}
+
+	stopped := false
+
+	for _, s := range steps {
+		c := check{Status: statusSkip, Detail: "skipped after an earlier failure"}
+
+		if !stopped {
+			c = s.fn()
+		}
+
+		c.Name = s.name
+
+		if c.Status == statusFail {
+			r.OK = false
+			stopped = stopped || s.fatal
+		}
+
+		r.Checks = append(r.Checks, c)
+	}
+
+	return r
+}
+
+func (d *doctor) checkConfig() check {
+	if err := settings.RequireConfig(); err != nil {
+		return check{Status: statusFail, Detail: err.Error(), Hint: "Run `proxmoxctl config set`."}
+	}
+
+	u, err := url.Parse(viper.GetString(settings.KeyServerURL))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Hostname() == "" {
+		return check{
+			Status: statusFail,
+			Detail: fmt.Sprintf("%q is not a server URL", viper.GetString(settings.KeyServerURL)),
+			Hint:   "Set server_url to e.g. https://192.168.1.10:8006 with `proxmoxctl config set server_url`.",
+		}
+	}
+
+	d.url = u
+
+	return check{Status: statusPass, Detail: u.String()}
+}
+
+func (d *doctor) checkTCP() check {
+	host := d.url.Hostname()
+
+	if net.ParseIP(host) == nil {
+		ctx, cancel := context.WithTimeout(d.ctx, dialTimeout)
+		defer cancel()
+
+		if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+			return check{
+				Status: statusFail,
+				Detail: fmt.Sprintf("cannot resolve %s: %v", host, err),
+				Hint:   "Check the host name and this machine's DNS settings, or use the node's IP address.",
+			}
+		}
+	}
+
+	port := d.url.Port()
+	if port == "" {
+		port = map[string]string{"https": "443", "http": "80"}[d.url.Scheme]
+	}
+
+	addr := net.JoinHostPort(host, port)
+	start := time.Now()
+
+	conn, err := (&net.Dialer{Timeout: dialTimeout}).DialContext(d.ctx, "tcp", addr)
+	if err != nil {
+		return check{
+			Status: statusFail,
+			Detail: err.Error(),
+			Hint:   fmt.Sprintf("Check that the node is up, that a firewall allows port %s, and that the URL uses the pveproxy port (8006).", port),
+		}
+	}
+
+	_ = conn.Close()
+
+	return check{Status: statusPass, Detail: fmt.Sprintf("%s reachable in %s", addr, time.Since(start).Round(time.Millisecond))}
+}
+
+func (d *doctor) checkTLS() check {
+	if d.url.Scheme != "https" {
+		return check{
+			Status: statusWarn,
+			Detail: "plain http; credentials are sent unencrypted",
+			Hint:   "Use the https:// URL of pveproxy, normally on port 8006.",
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(d.ctx, dialTimeout)
+	defer cancel()
+
+	cert, err := api.ProbeCertificate(ctx, d.url.String(), viper.GetString(api.KeyTLSCAFile))
+	if err != nil {
+		return check{
+			Status: statusFail,
+			Detail: fmt.Sprintf("handshake failed: %v", err),
+			Hint:   "Check that the port serves https (pveproxy listens on 8006).",
+		}
+	}
+
+	detail := fmt.Sprintf("SHA-256 %s, expires %s", cert.Fingerprint, cert.NotAfter.Local().Format("2006-01-02"))
+	pinned := viper.GetString(api.KeyTLSFingerprint)
+
+	switch {
+	case time.Now().After(cert.NotAfter):
+		return check{
+			Status: statusFail,
+			Detail: detail + " (expired)",
+			Hint:   "Renew the node certificate, e.g. with `pvenode acme cert renew` or `pvecm updatecerts --force`.",
+		}
+	case pinned != "" && !cert.Matches(pinned):
+		return check{
+			Status: statusFail,
+			Detail: detail + " (does not match tls_fingerprint)",
+			Hint:   "If the certificate was renewed on purpose, run `proxmoxctl config set` to trust the new one.",
+		}
+	case pinned == "" && !cert.Trusted && viper.GetBool(api.KeyInsecureSkipVerify):
+		return check{
+			Status: statusWarn,
+			Detail: detail + " (not verified: tls_insecure)",
+			Hint:   "Pin the certificate with `proxmoxctl config set` instead of disabling verification.",
+		}
+	case pinned == "" && !cert.Trusted:
+		return check{
+			Status: statusFail,
+			Detail: detail + " (not signed by a trusted CA)",
+			Hint:   "Run `proxmoxctl config set` to pin the fingerprint, or set tls_ca_file to your private CA bundle.",
+		}
+	case time.Until(cert.NotAfter) < certExpiryWarning:
+		return check{
+			Status: statusWarn,
+			Detail: detail + fmt.Sprintf(" (in %d days)", int(time.Until(cert.NotAfter).Hours()/24)),
+			Hint:   "Renew the node certificate before it expires.",
+		}
+	}
+
+	return check{Status: statusPass, Detail: detail}
+}
+
+func (d *doctor) checkAPI() check {
+	anon, err := api.NewAnonymous()
+	if err != nil {
+		return check{Status: statusFail, Detail: err.Error()}
+	}
+
+	// /version needs a login, so an authentication error still proves
+	// pveproxy is answering.
+	_, err = anon.Version(d.ctx)
+
+	var apiErr *api.Error
+	if err == nil || (errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized) {
+		return check{Status: statusPass, Detail: "pveproxy answered /version"}
+	}
+
+	hint := api.Diagnose(err).Hint
+	if hint == "" {
+		hint = "Check that server_url points at a Proxmox VE node and not at another service or proxy."
+	}
+
+	return check{Status: statusFail, Detail: err.Error(), Hint: hint}
+}
+
+func (d *doctor) checkAuth() check {
+	client, err := api.New(d.ctx)
+	if err != nil {
+		return check{
+			Status: statusFail,
+			Detail: err.Error(),
+			Hint:   "Set an API token with `proxmoxctl config set` or run `proxmoxctl login`.",
+		}
+	}
+
+	v, err := client.Version(d.ctx)
+	if err != nil {
+		return check{Status: statusFail, Detail: err.Error(), Hint: api.Diagnose(err).Hint}
+	}
+
+	d.client = client
+
+	source := api.TokenSource()
+	if source == "" {
+		source = "login session"
+	}
+
+	return check{Status: statusPass, Detail: fmt.Sprintf("Proxmox VE %s (%s), authenticated with %s", v.Version, v.Release, source)}
+}
+
+func (d *doctor) checkPrivileges() check {
+	perms, err := d.client.Access.Permissions(d.ctx, "")
+	if err != nil {
+		return check{Status: statusWarn, Detail: err.Error()}
+	}
+
+	held := map[string]bool{}
+
+	for _, privs := range perms {
+		for p := range privs {
+			held[p] = true
+		}
+	}
+
+	var missing []string
+
+	for _, p := range auditPrivileges {
+		if !held[p] {
+			missing = append(missing, p)
+		}
+	}
+
+	if len(missing) > 0 {
+		return check{
+			Status: statusWarn,
+			Detail: "missing " + strings.Join(missing, ", "),
+			Hint: "Grant a role such as PVEAuditor on / under Datacenter → Permissions; " +
+				"privilege-separated tokens need their own ACLs.",
+		}
+	}
+
+	return check{Status: statusPass, Detail: fmt.Sprintf("%d privileges on %d paths", len(held), len(perms))}
+}
+
+func (d *doctor) checkClock() check {
+	nodes, err := d.client.Nodes.List(d.ctx)
+	if err != nil {
+		return check{Status: statusWarn, Detail: err.Error()}
+	}
+
+	var (
+		worst     time.Duration
+		worstNode string
+	)
+
+	for _, n := range nodes {
+		if n.Status != "online" {
+			continue
+		}
+
+		before := time.Now()
+
+		t, err := d.client.Nodes.Time(d.ctx, n.Node)
+		if err != nil {
+			return check{Status: statusWarn, Detail: fmt.Sprintf("%s: %v", n.Node, err)}
+		}
+
+		// Compare against the middle of the round trip.
+		local := before.Add(time.Since(before) / 2)
+		skew := time.Unix(int64(t.Time), 0).Sub(local).Round(time.Second)
+
+		if math.Abs(float64(skew)) >= math.Abs(float64(worst)) {
+			worst, worstNode = skew, n.Node
+		}
+	}
+
+	if worstNode == "" {
+		return check{Status: statusWarn, Detail: "no online nodes to compare against"}
+	}
+
+	detail := fmt.Sprintf("largest skew %s (%s)", worst, worstNode)
+	hint := "Enable NTP (chrony) on the nodes and on this machine; login tickets and TOTP codes depend on accurate clocks."
+
+	switch abs := max(worst, -worst); {
+	case abs > failClockSkew:
+		return check{Status: statusFail, Detail: detail, Hint: hint}
+	case abs > warnClockSkew:
+		return check{Status: statusWarn, Detail: detail, Hint: hint}
+	}
+
+	return check{Status: statusPass, Detail: detail}
+}
+
+func (d *doctor) checkQuorum() check {
+	status, err := d.client.Cluster.Status(d.ctx)
+	if err != nil {
+		return check{Status: statusWarn, Detail: err.Error()}
+	}
+
+	var offline []string
+
+	for _, s := range status {
+		if s.Type == "node" && !s.Online {
+			offline = append(offline, s.Name)
+		}
+	}
+
+	slices.Sort(offline)
+
+	i := slices.IndexFunc(status, func(s proxmox.ClusterStatus) bool { return s.Type == "cluster" })
+	if i < 0 {
+		return check{Status: statusPass, Detail: "standalone node (no cluster)"}
+	}
+
+	cluster := status[i]
+
+	if !cluster.Quorate {
+		return check{
+			Status: statusFail,
+			Detail: fmt.Sprintf("cluster %s is not quorate", cluster.Name),
+			Hint:   "Most writes are refused without quorum. Run `pvecm status` on a node to check corosync.",
+		}
+	}
+
+	if len(offline) > 0 {
+		return check{
+			Status: statusWarn,
+			Detail: fmt.Sprintf("cluster %s is quorate; offline: %s", cluster.Name, strings.Join(offline, ", ")),
+			Hint:   "Guests on offline nodes cannot be managed until the nodes rejoin.",
+		}
+	}
+
+	return check{Status: statusPass, Detail: fmt.Sprintf("cluster %s is quorate, %d nodes online", cluster.Name, cluster.Nodes)}
+}
+
+func printReport(r report) {
+	target := r.Server
+	if r.Context != "" {
+		target += " (context " + r.Context + ")"
+	}
+
+	fmt.Printf("Checking %s\n\n", target)
+
+	failed, warned := 0, 0
+
+	for _, c := range r.Checks {
+		var mark string
+
+		switch c.Status {
+		case statusPass:
+			mark = color.Green("✓")
+		case statusWarn:
+			mark = color.Yellow("!")
+			warned++
+		case statusFail:
+			mark = color.Red("✗")
+			failed++
+		default:
+			mark = "-"
+		}
+
+		fmt.Printf("  %s %-11s %s\n", mark, c.Name, c.Detail)
+
+		if c.Hint != "" && c.Status != statusPass {
+			fmt.Printf("      %s%s\n", color.Teal("Hint: "), c.Hint)
+		}
+	}
+
+	fmt.Println()
+
+	switch {
+	case failed > 0:
+		fmt.Println(color.Red(fmt.Sprintf("%d check(s) failed, %d warning(s).", failed, warned)))
+	case warned > 0:
+		fmt.Println(color.Yellow(fmt.Sprintf("All checks passed with %d warning(s).", warned)))
+	default:
+		fmt.Println(color.Green("All checks passed."))
+	}
+}
@@ -30,7 +30,7 @@ func createCmd() *cobra.Command {
 		Short: "Create a new user group",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -43,9 +43,7 @@ func createCmd() *cobra.Command {
 				payload["comment"] = comment
 			}
 
-			var resp any
-
-			if err := client.Post("/access/groups", payload, &resp); err != nil {
+			if err := client.Access.CreateGroup(cmd.Context(), payload); err != nil {
 				return err
 			}
 
@@ -30,7 +30,7 @@ func deleteCmd() *cobra.Command {
 		Short: "Delete a user group",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -43,11 +43,11 @@ func deleteCmd() *cobra.Command {
 
 				if confirm != "y" && confirm != "Y" {
 					output.Aborted("Aborted.")
-					return nil
+					return api.ErrAborted
 				}
 			}
 
-			if err := client.Delete(fmt.Sprintf("/access/groups/%s", args[0])); err != nil {
+			if err := client.Access.DeleteGroup(cmd.Context(), args[0]); err != nil {
 				return err
 			}
 
@@ -16,8 +16,6 @@ limitations under the License.
 package group
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
@@ -35,11 +33,3 @@ func NewCommand() *cobra.Command {
 
 	return cmd
 }
-
-func toString(v any) string {
-	if v == nil {
-		return ""
-	}
-
-	return fmt.Sprintf("%v", v)
-}
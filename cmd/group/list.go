@@ -17,7 +17,9 @@ package group
 
 import (
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -26,33 +28,21 @@ func listCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List all user groups",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data []map[string]any `json:"data"`
-			}
-
-			if err := client.Get("/access/groups", &resp); err != nil {
+			groups, err := client.Access.Groups(cmd.Context())
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
-			}
-
-			headers := []string{"GROUP ID", "COMMENT"}
-			rows := make([][]string, 0, len(resp.Data))
-
-			for _, g := range resp.Data {
-				rows = append(rows, []string{toString(g["groupid"]), toString(g["comment"])})
-			}
-
-			output.Table(headers, rows)
-
-			return nil
+			return output.List(groups, []output.Column[proxmox.Group]{
+				{Header: "GROUP ID", Field: "groupid", Value: func(g proxmox.Group) string { return g.GroupID }, Key: true},
+				{Header: "COMMENT", Field: "comment", Value: func(g proxmox.Group) string { return g.Comment }},
+				{Header: "MEMBERS", Field: "users", Value: func(g proxmox.Group) string { return format.List(g.Users) }, Wide: true},
+			})
 		},
 	}
 }
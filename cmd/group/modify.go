@@ -30,7 +30,7 @@ func modifyCmd() *cobra.Command {
 		Short: "Modify a user group",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -45,7 +45,7 @@ func modifyCmd() *cobra.Command {
 				return fmt.Errorf("no changes specified — use --comment")
 			}
 
-			if err := client.Put(fmt.Sprintf("/access/groups/%s", args[0]), payload, nil); err != nil {
+			if err := client.Access.UpdateGroup(cmd.Context(), args[0], payload); err != nil {
 				return err
 			}
 
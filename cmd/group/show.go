@@ -16,9 +16,8 @@ limitations under the License.
 package group
 
 import (
-	"fmt"
-
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -29,28 +28,25 @@ func showCmd() *cobra.Command {
 		Short: "Show details of a user group",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data map[string]any `json:"data"`
-			}
-
-			if err := client.Get(fmt.Sprintf("/access/groups/%s", args[0]), &resp); err != nil {
+			group, err := client.Access.Group(cmd.Context(), args[0])
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsStructured() {
+				return output.Print(group)
 			}
 
 			headers := []string{"FIELD", "VALUE"}
-			rows := [][]string{}
-
-			for k, v := range resp.Data {
-				rows = append(rows, []string{k, fmt.Sprintf("%v", v)})
+			rows := [][]string{
+				{"Group ID", group.GroupID},
+				{"Comment", group.Comment},
+				{"Members", format.List(group.Members)},
 			}
 
 			output.Table(headers, rows)
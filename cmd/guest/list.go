@@ -0,0 +1,141 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package guest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/cobra"
+)
+
+// ListCmd builds the "list" command for guests of kind ("qemu" or "lxc").
+// extra columns are shown after MEM(MB).
+func ListCmd(kind, short, long string, extra ...output.Column[ContextGuest]) *cobra.Command {
+	var (
+		filter     api.GuestFilter
+		template   bool
+		noTemplate bool
+	)
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Short:       short,
+		Long:        long,
+		Annotations: map[string]string{api.FleetAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if template || noTemplate {
+				filter.Template = &template
+			}
+
+			columns := guestColumns(extra)
+
+			results := api.Fleet(cmd.Context(), func(ctx context.Context, c *api.Client) ([]proxmox.GuestSummary, error) {
+				return c.ListGuests(ctx, kind, filter, output.Shows("pool", columns))
+			})
+
+			if !api.AllContexts() {
+				if err := results[0].Err; err != nil {
+					return err
+				}
+			}
+
+			guests := make([]ContextGuest, 0)
+
+			for _, r := range results {
+				if r.Failed() {
+					guests = append(guests, ContextGuest{Context: r.Context, Error: r.Err.Error()})
+				}
+
+				for _, g := range r.Items {
+					guests = append(guests, ContextGuest{Context: r.Context, GuestSummary: g})
+				}
+			}
+
+			if err := output.List(guests, columns); err != nil {
+				return err
+			}
+
+			return api.FleetErrors(results)
+		},
+	}
+
+	cmd.Flags().StringVar(&filter.Node, "node", "", "Only list guests on this node, using the node's own listing")
+	cmd.Flags().StringVar(&filter.Status, "status", "", "Only list guests in this state (running or stopped)")
+	cmd.Flags().StringArrayVar(&filter.Tags, "tag", nil, "Only list guests with this tag (repeatable)")
+	cmd.Flags().StringVar(&filter.Pool, "pool", "", "Only list guests in this resource pool")
+	cmd.Flags().StringVar(&filter.Name, "name", "", "Only list guests whose name matches this glob (e.g. 'web-*')")
+	cmd.Flags().BoolVar(&template, "template", false, "Only list templates")
+	cmd.Flags().BoolVar(&noTemplate, "no-template", false, "Leave templates out")
+
+	cmd.MarkFlagsMutuallyExclusive("template", "no-template")
+
+	return cmd
+}
+
+// ContextGuest tags a guest with the context it came from in --all-contexts
+// mode. In structured output a context that failed gets one row carrying
+// only Context and Error.
+type ContextGuest struct {
+	Context string `json:"context,omitempty"`
+	Error   string `json:"error,omitempty"`
+	proxmox.GuestSummary
+}
+
+// MarshalJSON leaves the empty guest fields out of a failed context's row.
+func (g ContextGuest) MarshalJSON() ([]byte, error) {
+	if g.Error != "" {
+		return json.Marshal(api.FleetError{Context: g.Context, Error: g.Error})
+	}
+
+	type plain ContextGuest
+
+	return json.Marshal(plain(g))
+}
+
+// guestColumns lists the table columns; CONTEXT leads in --all-contexts mode.
+func guestColumns(extra []output.Column[ContextGuest]) []output.Column[ContextGuest] {
+	columns := []output.Column[ContextGuest]{
+		{Header: "VMID", Field: "vmid", Value: func(g ContextGuest) string { return fmt.Sprintf("%d", g.VMID) }, Key: true},
+		{Header: "NAME", Field: "name", Value: func(g ContextGuest) string { return g.Name }},
+		{Header: "STATUS", Field: "status", Value: func(g ContextGuest) string { return color.Status(g.Status) }},
+		{Header: "MEM(MB)", Field: "maxmem", Value: func(g ContextGuest) string { return format.MB(float64(g.MaxMem)) }},
+	}
+
+	columns = append(columns, extra...)
+
+	columns = append(columns, []output.Column[ContextGuest]{
+		{Header: "NODE", Field: "node", Value: func(g ContextGuest) string { return g.Node }},
+		{Header: "POOL", Field: "pool", Value: func(g ContextGuest) string { return g.Pool }, Wide: true},
+		{Header: "TAGS", Field: "tags", Value: func(g ContextGuest) string { return format.List(g.Tags) }, Wide: true},
+		{Header: "DISK", Field: "maxdisk", Value: func(g ContextGuest) string { return output.Bytes(float64(g.MaxDisk)) }, Wide: true},
+		{Header: "UPTIME", Field: "uptime", Value: func(g ContextGuest) string { return output.Uptime(int64(g.Uptime)) }, Wide: true},
+	}...)
+
+	if api.AllContexts() {
+		columns = append([]output.Column[ContextGuest]{
+			{Header: "CONTEXT", Field: "context", Value: func(g ContextGuest) string { return g.Context }},
+		}, columns...)
+	}
+
+	return columns
+}
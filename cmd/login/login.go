@@ -0,0 +1,146 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package login
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/prompt"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCommand() *cobra.Command {
+	var (
+		username string
+		otp      string
+		recovery string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in with a username and password instead of an API token",
+		Long: `Log in with a username and password and cache the session ticket.
+
+The ticket is stored in ~/.config/proxmoxctl/ticket.json (mode 0600), or in
+ticket-<context>.json when a context is active, so each context keeps its own
+session. It is used by every command when no api_token is configured. Proxmox tickets expire
+after two hours; commands renew the ticket automatically once it is an hour
+old, so only a session left idle for two hours needs a new login.
+
+Accounts with a second factor are asked for a TOTP code, or pass --otp. Use
+--recovery to log in with a recovery key instead.
+
+Examples:
+  proxmoxctl login --username root@pam
+  proxmoxctl login --username admin@pve --otp 123456`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := api.NewAnonymous()
+			if err != nil {
+				return err
+			}
+
+			reader := bufio.NewReader(os.Stdin)
+
+			if username == "" {
+				username = viper.GetString(settings.KeyUsername)
+			}
+
+			if username == "" {
+				fmt.Print(color.Green("Username (user@realm): "))
+				line, _ := reader.ReadString('\n')
+				username = strings.TrimSpace(line)
+			}
+
+			if username == "" {
+				return fmt.Errorf("username cannot be empty")
+			}
+
+			if !strings.Contains(username, "@") {
+				username += "@pam"
+			}
+
+			password := prompt.Secret(reader, "Password: ")
+
+			ticket, err := client.Access.Login(cmd.Context(), username, password)
+			if err != nil {
+				return err
+			}
+
+			if ticket.NeedTFA {
+				response, err := tfaResponse(reader, ticket, otp, recovery)
+				if err != nil {
+					return err
+				}
+
+				if ticket, err = client.Access.CompleteTFA(cmd.Context(), ticket, response); err != nil {
+					return err
+				}
+			}
+
+			if err := api.SaveTicket(ticket); err != nil {
+				return err
+			}
+
+			output.Success(fmt.Sprintf("Logged in as %s", ticket.Username))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "User to log in as, e.g. root@pam (default: username from config)")
+	cmd.Flags().StringVar(&otp, "otp", "", "TOTP code for accounts with a second factor")
+	cmd.Flags().StringVar(&recovery, "recovery", "", "Recovery key to use instead of a TOTP code")
+
+	return cmd
+}
+
+// tfaResponse returns the second factor in the "<type>:<value>" form the
+// ticket endpoint expects, prompting for a TOTP code when no flag gave one.
+func tfaResponse(reader *bufio.Reader, ticket *proxmox.Ticket, otp, recovery string) (string, error) {
+	challenge, err := ticket.Challenge()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case recovery != "":
+		if !challenge.Offers("recovery") {
+			return "", fmt.Errorf("no recovery keys are set up for %s", ticket.Username)
+		}
+
+		return "recovery:" + recovery, nil
+	case challenge.Offers("totp"):
+		if otp == "" {
+			otp = prompt.Secret(reader, "TOTP code: ")
+		}
+
+		return "totp:" + otp, nil
+	case challenge.Offers("recovery"):
+		return "recovery:" + prompt.Secret(reader, "Recovery key: "), nil
+	}
+
+	return "", fmt.Errorf("unsupported second factor (%s) — use an API token instead",
+		strings.Join(challenge.Methods, ", "))
+}
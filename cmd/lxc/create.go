@@ -32,13 +32,13 @@ func createCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create a new LXC container",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			if node == "" {
-				node, err = client.DefaultNode()
+				node, err = client.DefaultNode(cmd.Context())
 				if err != nil {
 					return err
 				}
@@ -55,13 +55,21 @@ func createCmd() *cobra.Command {
 				"net0":       "name=eth0,bridge=vmbr0,ip=dhcp",
 			}
 
-			var resp any
+			upid, err := client.LXC.Create(cmd.Context(), node, payload)
+			if err != nil {
+				return err
+			}
 
-			if err := client.Post(fmt.Sprintf("/nodes/%s/lxc", node), payload, &resp); err != nil {
+			if err := client.Await(cmd.Context(), node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf("LXC container %d (%s) creation task queued on node %s", vmid, hostname, node))
+			output.Done(output.Result{
+				Message: fmt.Sprintf("LXC container %d (%s) creation task %s on node %s", vmid, hostname, api.TaskState(), node),
+				UPID:    upid,
+				Node:    node,
+				VMID:    vmid,
+			})
 
 			return nil
 		},
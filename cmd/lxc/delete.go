@@ -28,39 +28,47 @@ func deleteCmd() *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
-		Use:   "delete <vmid>",
+		Use:   "delete <vmid|name>",
 		Short: "Delete an LXC container",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
-				}
+			guest, err := client.ResolveGuest(cmd.Context(), args[0], node, "lxc")
+			if err != nil {
+				return err
 			}
 
 			if !force {
 				var confirm string
 
-				fmt.Printf("Are you sure you want to delete container %s? [y/N]: ", args[0])
+				fmt.Printf("Are you sure you want to delete container %d? [y/N]: ", guest.VMID)
 				_, _ = fmt.Scanln(&confirm)
 
 				if confirm != "y" && confirm != "Y" {
 					output.Aborted("Aborted.")
-					return nil
+					return api.ErrAborted
 				}
 			}
 
-			if err := client.Delete(fmt.Sprintf("/nodes/%s/lxc/%s", node, args[0])); err != nil {
+			upid, err := client.LXC.Delete(cmd.Context(), guest.Node, guest.VMID)
+			if err != nil {
+				return err
+			}
+
+			if err := client.Await(cmd.Context(), guest.Node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf("LXC container %s deleted", args[0]))
+			output.Done(output.Result{
+				Message: fmt.Sprintf("LXC container %d delete task %s", guest.VMID, api.TaskState()),
+				UPID:    upid,
+				Node:    guest.Node,
+				VMID:    guest.VMID,
+			})
 
 			return nil
 		},
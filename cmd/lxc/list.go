@@ -16,62 +16,18 @@ limitations under the License.
 package lxc
 
 import (
-	"fmt"
-
-	"github.com/dcjulian29/proxmoxctl/internal/api"
-	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/cmd/guest"
 	"github.com/spf13/cobra"
 )
 
 func listCmd() *cobra.Command {
-	var node string
-	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List all LXC containers",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
-			if err != nil {
-				return err
-			}
-
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
-				}
-			}
-
-			var resp struct {
-				Data []map[string]any `json:"data"`
-			}
-
-			if err := client.Get(fmt.Sprintf("/nodes/%s/lxc", node), &resp); err != nil {
-				return err
-			}
-
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
-			}
-
-			headers := []string{"VMID", "NAME", "STATUS", "MEM(MB)"}
-			rows := make([][]string, 0, len(resp.Data))
-
-			for _, v := range resp.Data {
-				rows = append(rows, []string{
-					fmt.Sprintf("%.0f", toFloat(v["vmid"])),
-					toString(v["name"]),
-					toString(v["status"]),
-					fmt.Sprintf("%.0f", toFloat(v["maxmem"])/1024/1024),
-				})
-			}
-
-			output.Table(headers, rows)
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&node, "node", "", "Proxmox node name")
-
-	return cmd
+	return guest.ListCmd("lxc", "List LXC containers across the cluster", `List containers on every node of the cluster, from /cluster/resources.
+With --node, the node's own listing is used instead. The remaining flags
+filter the result; --tag may be repeated and all tags must match.
+
+Examples:
+  proxmoxctl lxc list
+  proxmoxctl lxc list --status running --pool lab
+  proxmoxctl lxc list --name 'ct-*'
+  proxmoxctl lxc list --node pve2`)
 }
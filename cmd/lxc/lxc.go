@@ -16,8 +16,8 @@ limitations under the License.
 package lxc
 
 import (
+	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
@@ -41,48 +41,32 @@ func NewCommand() *cobra.Command {
 	return cmd
 }
 
-func lxcPowerAction(node, vmid, action string) error {
-	client, err := api.New()
+func lxcPowerAction(ctx context.Context, node, vmid, action string) error {
+	client, err := api.New(ctx)
 	if err != nil {
 		return err
 	}
 
-	if node == "" {
-		var err error
-
-		node, err = client.DefaultNode()
-		if err != nil {
-			return err
-		}
+	guest, err := client.ResolveGuest(ctx, vmid, node, "lxc")
+	if err != nil {
+		return err
 	}
 
-	var resp any
-
-	if err := client.Post(fmt.Sprintf("/nodes/%s/lxc/%s/status/%s", node, vmid, action), nil, &resp); err != nil {
+	upid, err := client.LXC.Action(ctx, guest.Node, guest.VMID, action)
+	if err != nil {
 		return err
 	}
 
-	output.Success(fmt.Sprintf("LXC container %s %s task queued", vmid, action))
-
-	return nil
-}
-
-func toFloat(v any) float64 {
-	switch val := v.(type) {
-	case float64:
-		return val
-	case string:
-		f, _ := strconv.ParseFloat(val, 64)
-		return f
+	if err := client.Await(ctx, guest.Node, upid); err != nil {
+		return err
 	}
 
-	return 0
-}
+	output.Done(output.Result{
+		Message: fmt.Sprintf("LXC container %d %s task %s", guest.VMID, action, api.TaskState()),
+		UPID:    upid,
+		Node:    guest.Node,
+		VMID:    guest.VMID,
+	})
 
-func toString(v any) string {
-	if v == nil {
-		return ""
-	}
-
-	return fmt.Sprintf("%v", v)
+	return nil
 }
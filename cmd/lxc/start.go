@@ -23,11 +23,11 @@ func startCmd() *cobra.Command {
 	var node string
 
 	cmd := &cobra.Command{
-		Use:   "start <vmid>",
+		Use:   "start <vmid|name>",
 		Short: "Start an LXC container",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return lxcPowerAction(node, args[0], "start")
+			return lxcPowerAction(cmd.Context(), node, args[0], "start")
 		},
 	}
 
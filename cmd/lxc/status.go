@@ -19,6 +19,8 @@ import (
 	"fmt"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -26,42 +28,37 @@ import (
 func statusCmd() *cobra.Command {
 	var node string
 	cmd := &cobra.Command{
-		Use:   "status <vmid>",
+		Use:   "status <vmid|name>",
 		Short: "Show status of an LXC container",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
-				}
-			}
-
-			var resp struct {
-				Data map[string]any `json:"data"`
+			guest, err := client.ResolveGuest(cmd.Context(), args[0], node, "lxc")
+			if err != nil {
+				return err
 			}
 
-			if err := client.Get(fmt.Sprintf("/nodes/%s/lxc/%s/status/current", node, args[0]), &resp); err != nil {
+			d, err := client.LXC.Status(cmd.Context(), guest.Node, guest.VMID)
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsStructured() {
+				return output.Print(d)
 			}
 
 			headers := []string{"FIELD", "VALUE"}
 			rows := [][]string{
-				{"VMID", toString(resp.Data["vmid"])},
-				{"Name", toString(resp.Data["name"])},
-				{"Status", toString(resp.Data["status"])},
-				{"CPU Usage", fmt.Sprintf("%.2f%%", toFloat(resp.Data["cpu"])*100)},
-				{"Memory", fmt.Sprintf("%.0f / %.0f MB", toFloat(resp.Data["mem"])/1024/1024, toFloat(resp.Data["maxmem"])/1024/1024)},
-				{"Uptime", fmt.Sprintf("%.0fs", toFloat(resp.Data["uptime"]))},
+				{"VMID", fmt.Sprintf("%d", d.VMID)},
+				{"Name", d.Name},
+				{"Status", color.Status(d.Status)},
+				{"CPU Usage", output.Percent(float64(d.CPU))},
+				{"Memory", fmt.Sprintf("%s / %s MB", format.MB(float64(d.Mem)), format.MB(float64(d.MaxMem)))},
+				{"Uptime", output.Uptime(int64(d.Uptime))},
 			}
 
 			output.Table(headers, rows)
@@ -23,11 +23,11 @@ func stopCmd() *cobra.Command {
 	var node string
 
 	cmd := &cobra.Command{
-		Use:   "stop <vmid>",
+		Use:   "stop <vmid|name>",
 		Short: "Stop an LXC container",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return lxcPowerAction(node, args[0], "stop")
+			return lxcPowerAction(cmd.Context(), node, args[0], "stop")
 		},
 	}
 
@@ -16,28 +16,44 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/dcjulian29/proxmoxctl/cmd/backup"
 	"github.com/dcjulian29/proxmoxctl/cmd/clone"
 	"github.com/dcjulian29/proxmoxctl/cmd/config"
+	"github.com/dcjulian29/proxmoxctl/cmd/doctor"
 	"github.com/dcjulian29/proxmoxctl/cmd/group"
+	"github.com/dcjulian29/proxmoxctl/cmd/login"
 	"github.com/dcjulian29/proxmoxctl/cmd/lxc"
 	"github.com/dcjulian29/proxmoxctl/cmd/snapshot"
 	"github.com/dcjulian29/proxmoxctl/cmd/status"
 	"github.com/dcjulian29/proxmoxctl/cmd/storage"
+	"github.com/dcjulian29/proxmoxctl/cmd/task"
 	"github.com/dcjulian29/proxmoxctl/cmd/user"
 	"github.com/dcjulian29/proxmoxctl/cmd/vm"
 	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/color"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.szostok.io/version/extension"
 )
 
+// cancelGrace is how long a command gets to unwind after Ctrl-C before the
+// process exits anyway (e.g. when it is blocked on a confirmation prompt).
+const cancelGrace = 2 * time.Second
+
+// exitCancelled follows the shell convention for termination by SIGINT.
+const exitCancelled = 130
+
 var cfgFile string
 
 var rootCmd = &cobra.Command{
@@ -58,19 +74,46 @@ RESOURCES
 
 OBSERVABILITY
   status      Cluster health, per-node resource usage, resource inventory, and task history
+  task        Inspect, follow the log of, and stop individual tasks by UPID
   version     Show tool version and check for updates
 
 CONFIGURATION
-  config      Set and display connection settings (server URL, username, API token)
+  config      Set and display connection settings and switch between named contexts
+  doctor      Diagnose connectivity, TLS, credentials, privileges, clock skew, and quorum
+  login       Log in with a username, password, and optional TOTP instead of a token
+
+All commands support --output table (default), json, yaml, csv, or tsv for
+scripting and piping; --no-headers drops the header row from tables. Single
+fields can be extracted with -o go-template=..., -o go-template-file=<path>,
+or -o jsonpath=..., which see the same fields as -o json. List commands accept
+--columns, --sort-by, and --reverse, and -o wide shows extra columns; -q
+prints only the key of each row, or the UPID of a queued task.
+
+Commands that queue a Proxmox task return as soon as it is queued; pass --wait
+(and optionally --timeout) to block until the task ends and exit non-zero if
+it failed. Each API request gives up after --request-timeout (default 2m),
+and Ctrl-C cancels the command cleanly. Destructive operations prompt for
+confirmation unless --force is passed. The --node flag is optional on all
+node-scoped commands — guest commands locate the node that owns the VMID, and
+everything else uses the first available cluster node when omitted. Guests
+can be addressed by name wherever a VMID is accepted (name:42 for a guest
+whose name is a number); a name shared by several guests is rejected.
+
+Exit codes: 0 success, 1 failure, 3 permission denied, 4 not found,
+5 conflict or locked, 6 unreachable, 7 aborted, 8 timed out, 130 cancelled.
 
-All commands support --output table (default) or --output json (-o json) for
-scripting and piping. Destructive operations prompt for confirmation unless
---force is passed. The --node flag is optional on all node-scoped commands —
-the first available cluster node is used when omitted.
+To manage several clusters, add a named context for each with
+'proxmoxctl config add-context' and pick one with 'config use-context' or
+--context (or PROXMOX_CONTEXT) for a single command. Listing commands such as
+'status resources', 'vm list', and 'lxc list' accept --all-contexts to query
+every context in parallel and merge the results with a CONTEXT column.
 
 Run 'proxmoxctl config set' to get started.`,
 	SilenceErrors: true,
 	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return api.CheckFleet(cmd)
+	},
 }
 
 func Execute() {
@@ -80,37 +123,129 @@ func Execute() {
 		),
 	)
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, "\n"+color.Fatal(err))
-		os.Exit(1)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		time.Sleep(cancelGrace)
+		cancelled()
+	}()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		if ctx.Err() != nil {
+			cancelled()
+		}
+
+		os.Exit(api.ReportError(err))
 	}
 }
 
+func cancelled() {
+	output.Cancelled("Cancelled.")
+	os.Exit(exitCancelled)
+}
+
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "specify configuration file")
-	rootCmd.PersistentFlags().StringP("output", "o", "table", "output format (table or json)")
-	rootCmd.PersistentFlags().Bool("insecure", false, "disable TLS certificate verification (not recommended)")
+	rootCmd.PersistentFlags().String("context", "", "use this named context instead of current_context")
+	rootCmd.PersistentFlags().Bool("all-contexts", false, "run a read-only listing command against every context")
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "output format: table, wide, json, yaml, csv, tsv, go-template=..., go-template-file=..., or jsonpath=...")
+	rootCmd.PersistentFlags().Bool("no-headers", false, "omit the header row from table, csv, and tsv output")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "print only identifiers: the key of each listed item, or the UPID of a queued task")
+	rootCmd.PersistentFlags().String("columns", "", "comma-separated fields to show in lists (prefix with + to add to the defaults)")
+	rootCmd.PersistentFlags().String("sort-by", "", "sort lists by this field")
+	rootCmd.PersistentFlags().Bool("reverse", false, "reverse the order of lists")
+	rootCmd.PersistentFlags().Bool("no-color", false, "disable colored output (also NO_COLOR)")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "log API retries and other diagnostics to stderr")
+	rootCmd.PersistentFlags().Bool("insecure", false, "disable TLS certificate verification (not recommended; pin tls_fingerprint instead)")
+	rootCmd.PersistentFlags().Bool("wait", false, "wait for queued tasks to finish and stream their log")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "maximum time to wait for a task with --wait (0 = no limit)")
+	rootCmd.PersistentFlags().Duration("request-timeout", 2*time.Minute, "maximum time for a single API request (0 = no limit)")
 
 	cobra.OnInitialize(initConfig)
 
+	if err := viper.BindPFlag(settings.KeyContext, rootCmd.PersistentFlags().Lookup("context")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(api.KeyAllContexts, rootCmd.PersistentFlags().Lookup("all-contexts")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
 	if err := viper.BindPFlag(output.KeyOutputFormat, rootCmd.PersistentFlags().Lookup("output")); err != nil {
 		fmt.Fprintln(os.Stderr, color.Fatal(err))
 		os.Exit(1)
 	}
 
+	if err := viper.BindPFlag(output.KeyNoHeaders, rootCmd.PersistentFlags().Lookup("no-headers")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(color.KeyNoColor, rootCmd.PersistentFlags().Lookup("no-color")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(output.KeyQuiet, rootCmd.PersistentFlags().Lookup("quiet")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(output.KeyColumns, rootCmd.PersistentFlags().Lookup("columns")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(output.KeySortBy, rootCmd.PersistentFlags().Lookup("sort-by")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(output.KeyReverse, rootCmd.PersistentFlags().Lookup("reverse")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
 	if err := viper.BindPFlag(api.KeyInsecureSkipVerify, rootCmd.PersistentFlags().Lookup("insecure")); err != nil {
 		fmt.Fprintln(os.Stderr, color.Fatal(err))
 		os.Exit(1)
 	}
 
+	if err := viper.BindPFlag(api.KeyWait, rootCmd.PersistentFlags().Lookup("wait")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(api.KeyWaitTimeout, rootCmd.PersistentFlags().Lookup("timeout")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(api.KeyRequestTimeout, rootCmd.PersistentFlags().Lookup("request-timeout")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
+	if err := viper.BindPFlag(api.KeyVerbose, rootCmd.PersistentFlags().Lookup("verbose")); err != nil {
+		fmt.Fprintln(os.Stderr, color.Fatal(err))
+		os.Exit(1)
+	}
+
 	rootCmd.AddCommand(backup.NewCommand())
 	rootCmd.AddCommand(clone.NewCommand())
 	rootCmd.AddCommand(config.NewCommand())
+	rootCmd.AddCommand(doctor.NewCommand())
 	rootCmd.AddCommand(group.NewCommand())
+	rootCmd.AddCommand(login.NewCommand())
 	rootCmd.AddCommand(lxc.NewCommand())
 	rootCmd.AddCommand(status.NewCommand())
 	rootCmd.AddCommand(snapshot.NewCommand())
 	rootCmd.AddCommand(storage.NewCommand())
+	rootCmd.AddCommand(task.NewCommand())
 	rootCmd.AddCommand(user.NewCommand())
 	rootCmd.AddCommand(vm.NewCommand())
 }
@@ -136,4 +271,15 @@ func initConfig() {
 			os.Exit(1)
 		}
 	}
+
+	// An unknown context is reported once a command needs the server, so
+	// the config commands can still repair it.
+	if err := settings.UseContext(settings.SelectedContext()); err != nil && !errors.Is(err, settings.ErrNoContext) {
+		fmt.Fprintln(os.Stderr, color.Fatal("error reading config:", err))
+		os.Exit(1)
+	}
+
+	if viper.GetBool(color.KeyNoColor) {
+		color.Disable()
+	}
 }
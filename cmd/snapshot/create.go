@@ -28,16 +28,16 @@ func createCmd() *cobra.Command {
 	var vmstate bool
 
 	cmd := &cobra.Command{
-		Use:   "create <vmid>",
+		Use:   "create <vmid|name>",
 		Short: "Create a snapshot of a VM or container",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			node, err = resolveNode(client, node)
+			guest, err := resolveGuest(cmd, client, args[0], node, gtype)
 			if err != nil {
 				return err
 			}
@@ -50,19 +50,25 @@ func createCmd() *cobra.Command {
 				payload["description"] = description
 			}
 
-			if vmstate && gtype == "qemu" {
+			if vmstate && guest.Type == "qemu" {
 				payload["vmstate"] = 1
 			}
 
-			var resp any
-
-			path := fmt.Sprintf("/nodes/%s/%s/%s/snapshot", node, gtype, args[0])
+			upid, err := client.GuestService(guest.Type).CreateSnapshot(cmd.Context(), guest.Node, guest.VMID, payload)
+			if err != nil {
+				return err
+			}
 
-			if err := client.Post(path, payload, &resp); err != nil {
+			if err := client.Await(cmd.Context(), guest.Node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf("Snapshot '%s' of %s %s creation task queued", snapname, gtype, args[0]))
+			output.Done(output.Result{
+				Message: fmt.Sprintf("Snapshot '%s' of %s %d creation task %s", snapname, guest.Type, guest.VMID, api.TaskState()),
+				UPID:    upid,
+				Node:    guest.Node,
+				VMID:    guest.VMID,
+			})
 
 			return nil
 		},
@@ -28,16 +28,16 @@ func deleteCmd() *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
-		Use:   "delete <vmid>",
+		Use:   "delete <vmid|name>",
 		Short: "Delete a snapshot",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			node, err = resolveNode(client, node)
+			guest, err := resolveGuest(cmd, client, args[0], node, gtype)
 			if err != nil {
 				return err
 			}
@@ -45,22 +45,30 @@ func deleteCmd() *cobra.Command {
 			if !force {
 				var confirm string
 
-				fmt.Printf("Delete snapshot '%s' from %s %s? [y/N]: ", snapname, gtype, args[0])
+				fmt.Printf("Delete snapshot '%s' from %s %d? [y/N]: ", snapname, guest.Type, guest.VMID)
 				_, _ = fmt.Scanln(&confirm)
 
 				if confirm != "y" && confirm != "Y" {
 					output.Aborted("Aborted.")
-					return nil
+					return api.ErrAborted
 				}
 			}
 
-			path := fmt.Sprintf("/nodes/%s/%s/%s/snapshot/%s", node, gtype, args[0], snapname)
+			upid, err := client.GuestService(guest.Type).DeleteSnapshot(cmd.Context(), guest.Node, guest.VMID, snapname)
+			if err != nil {
+				return err
+			}
 
-			if err := client.Delete(path); err != nil {
+			if err := client.Await(cmd.Context(), guest.Node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf("Snapshot '%s' deleted from %s %s", snapname, gtype, args[0]))
+			output.Done(output.Result{
+				Message: fmt.Sprintf("Snapshot '%s' of %s %d delete task %s", snapname, guest.Type, guest.VMID, api.TaskState()),
+				UPID:    upid,
+				Node:    guest.Node,
+				VMID:    guest.VMID,
+			})
 
 			return nil
 		},
@@ -19,7 +19,9 @@ import (
 	"fmt"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -27,59 +29,52 @@ func listCmd() *cobra.Command {
 	var node, gtype string
 
 	cmd := &cobra.Command{
-		Use:   "list <vmid>",
+		Use:   "list <vmid|name>",
 		Short: "List snapshots for a VM or container",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			node, err = resolveNode(client, node)
+			guest, err := resolveGuest(cmd, client, args[0], node, gtype)
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data []map[string]any `json:"data"`
+			snapshots, err := client.GuestService(guest.Type).Snapshots(cmd.Context(), guest.Node, guest.VMID)
+			if err != nil {
+				return err
 			}
 
-			path := fmt.Sprintf("/nodes/%s/%s/%s/snapshot", node, gtype, args[0])
-
-			if err := client.Get(path, &resp); err != nil {
-				return err
+			columns := []output.Column[proxmox.Snapshot]{
+				{Header: "NAME", Field: "name", Value: func(s proxmox.Snapshot) string { return s.Name }, Key: true},
+				{Header: "DESCRIPTION", Field: "description", Value: func(s proxmox.Snapshot) string { return s.Description }},
+				{Header: "VMSTATE", Field: "vmstate", Value: func(s proxmox.Snapshot) string { return format.Bool(bool(s.VMState)) }},
+				{Header: "CREATED", Field: "snaptime", Value: func(s proxmox.Snapshot) string { return format.Epoch(int64(s.SnapTime)) }},
+				{Header: "PARENT", Field: "parent", Value: func(s proxmox.Snapshot) string { return s.Parent }, Wide: true},
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsStructured() && !output.IsQuiet() {
+				return output.List(snapshots, columns)
 			}
 
-			headers := []string{"NAME", "DESCRIPTION", "VMSTATE", "CREATED"}
-			rows := make([][]string, 0, len(resp.Data))
+			// Skip the synthetic "current" entry Proxmox always returns
+			shown := make([]proxmox.Snapshot, 0, len(snapshots))
 
-			for _, s := range resp.Data {
-				// Skip the synthetic "current" entry Proxmox always returns
-				if toString(s["name"]) == "current" {
-					continue
+			for _, s := range snapshots {
+				if s.Name != "current" {
+					shown = append(shown, s)
 				}
-
-				rows = append(rows, []string{
-					toString(s["name"]),
-					toString(s["description"]),
-					fmt.Sprintf("%v", s["vmstate"] == true || s["vmstate"] == 1.0),
-					formatEpoch(s["snaptime"]),
-				})
 			}
 
-			if len(rows) == 0 {
-				fmt.Printf("No snapshots found for %s %s.\n", gtype, args[0])
+			if len(shown) == 0 && !output.IsBare() {
+				fmt.Printf("No snapshots found for %s %d.\n", guest.Type, guest.VMID)
 				return nil
 			}
 
-			output.Table(headers, rows)
-
-			return nil
+			return output.List(shown, columns)
 		},
 	}
 
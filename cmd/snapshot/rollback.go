@@ -28,16 +28,16 @@ func rollbackCmd() *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
-		Use:   "rollback <vmid>",
+		Use:   "rollback <vmid|name>",
 		Short: "Roll back a VM or container to a snapshot",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			node, err = resolveNode(client, node)
+			guest, err := resolveGuest(cmd, client, args[0], node, gtype)
 			if err != nil {
 				return err
 			}
@@ -45,25 +45,31 @@ func rollbackCmd() *cobra.Command {
 			if !force {
 				var confirm string
 
-				fmt.Printf("Roll back %s %s to snapshot '%s'? This cannot be undone. [y/N]: ",
-					gtype, args[0], snapname)
+				fmt.Printf("Roll back %s %d to snapshot '%s'? This cannot be undone. [y/N]: ",
+					guest.Type, guest.VMID, snapname)
 				_, _ = fmt.Scanln(&confirm)
 
 				if confirm != "y" && confirm != "Y" {
 					output.Aborted("Rollback aborted.")
-					return nil
+					return api.ErrAborted
 				}
 			}
 
-			var resp any
-
-			path := fmt.Sprintf("/nodes/%s/%s/%s/snapshot/%s/rollback", node, gtype, args[0], snapname)
+			upid, err := client.GuestService(guest.Type).RollbackSnapshot(cmd.Context(), guest.Node, guest.VMID, snapname)
+			if err != nil {
+				return err
+			}
 
-			if err := client.Post(path, nil, &resp); err != nil {
+			if err := client.Await(cmd.Context(), guest.Node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf("Rollback of %s %s to snapshot '%s' task queued", gtype, args[0], snapname))
+			output.Done(output.Result{
+				Message: fmt.Sprintf("Rollback of %s %d to snapshot '%s' task %s", guest.Type, guest.VMID, snapname, api.TaskState()),
+				UPID:    upid,
+				Node:    guest.Node,
+				VMID:    guest.VMID,
+			})
 
 			return nil
 		},
@@ -16,8 +16,6 @@ limitations under the License.
 package snapshot
 
 import (
-	"fmt"
-
 	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
 	"github.com/spf13/cobra"
@@ -27,39 +25,34 @@ func showCmd() *cobra.Command {
 	var node, gtype, snapname string
 
 	cmd := &cobra.Command{
-		Use:   "show <vmid>",
+		Use:   "show <vmid|name>",
 		Short: "Show config stored in a specific snapshot",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			node, err = resolveNode(client, node)
+			guest, err := resolveGuest(cmd, client, args[0], node, gtype)
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data map[string]any `json:"data"`
-			}
-
-			path := fmt.Sprintf("/nodes/%s/%s/%s/snapshot/%s/config", node, gtype, args[0], snapname)
-
-			if err := client.Get(path, &resp); err != nil {
+			config, err := client.GuestService(guest.Type).SnapshotConfig(cmd.Context(), guest.Node, guest.VMID, snapname)
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsStructured() {
+				return output.Print(config)
 			}
 
 			headers := []string{"FIELD", "VALUE"}
 			rows := [][]string{}
 
-			for k, v := range resp.Data {
-				rows = append(rows, []string{k, fmt.Sprintf("%v", v)})
+			for _, k := range config.Keys() {
+				rows = append(rows, []string{k, string(config[k])})
 			}
 
 			output.Table(headers, rows)
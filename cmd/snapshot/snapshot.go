@@ -16,9 +16,6 @@ limitations under the License.
 package snapshot
 
 import (
-	"fmt"
-	"time"
-
 	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/spf13/cobra"
 )
@@ -29,7 +26,9 @@ func NewCommand() *cobra.Command {
 		Short: "Manage snapshots for VMs and LXC containers",
 		Long: `Manage snapshots for both KVM VMs and LXC containers.
 
-Use --type to target a VM (qemu, default) or an LXC container (lxc).
+The guest type and owning node are detected from the cluster. Use --type
+to target a VM (qemu, default) or an LXC container (lxc) when --node is
+given explicitly.
 
 Examples:
   proxmoxctl snapshot list 100
@@ -48,30 +47,21 @@ Examples:
 	return cmd
 }
 
-func formatEpoch(v any) string {
-	if v == nil {
-		return ""
+func resolveGuest(cmd *cobra.Command, client *api.Client, vmid, node, gtype string) (*api.Guest, error) {
+	// Without an explicit --type the located guest decides between qemu and lxc.
+	want := gtype
+	if !cmd.Flags().Changed("type") {
+		want = ""
 	}
 
-	if val, ok := v.(float64); ok {
-		return time.Unix(int64(val), 0).Format("2006-01-02 15:04:05")
+	guest, err := client.ResolveGuest(cmd.Context(), vmid, node, want)
+	if err != nil {
+		return nil, err
 	}
 
-	return fmt.Sprintf("%v", v)
-}
-
-func resolveNode(client *api.Client, node string) (string, error) {
-	if node != "" {
-		return node, nil
-	}
-
-	return client.DefaultNode()
-}
-
-func toString(v any) string {
-	if v == nil {
-		return ""
+	if guest.Type == "" {
+		guest.Type = gtype
 	}
 
-	return fmt.Sprintf("%v", v)
+	return guest, nil
 }
@@ -17,9 +17,13 @@ package status
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -28,63 +32,79 @@ func clusterStatusCmd() *cobra.Command {
 		Use:   "cluster",
 		Short: "Show cluster-wide health and node summary",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data []map[string]any `json:"data"`
-			}
-
-			if err := client.Get("/cluster/status", &resp); err != nil {
+			status, err := client.Cluster.Status(cmd.Context())
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsStructured() {
+				return output.Print(status)
 			}
 
-			var clusterInfo map[string]any
-			var nodes []map[string]any
+			var clusterInfo *proxmox.ClusterStatus
+			var members []proxmox.ClusterStatus
 
-			for _, item := range resp.Data {
-				switch toString(item["type"]) {
+			for i, item := range status {
+				switch item.Type {
 				case "cluster":
-					clusterInfo = item
+					clusterInfo = &status[i]
 				case "node":
-					nodes = append(nodes, item)
+					members = append(members, item)
 				}
 			}
 
-			if clusterInfo != nil {
+			// CSV and TSV carry the node table only, so the file stays one table.
+			if clusterInfo != nil && !output.IsDelimited() {
 				fmt.Println()
 				printSectionHeader("CLUSTER")
+
 				headers := []string{"FIELD", "VALUE"}
 				rows := [][]string{
-					{"Name", toString(clusterInfo["name"])},
-					{"Quorum", formatBool(clusterInfo["quorate"])},
-					{"Nodes (total)", fmt.Sprintf("%.0f", toFloat(clusterInfo["nodes"]))},
-					{"Version", toString(clusterInfo["version"])},
+					{"Name", clusterInfo.Name},
+					{"Quorum", format.Bool(bool(clusterInfo.Quorate))},
+					{"Nodes (total)", strconv.FormatInt(int64(clusterInfo.Nodes), 10)},
+					{"Version", strconv.FormatInt(int64(clusterInfo.Version), 10)},
 				}
 
 				output.Table(headers, rows)
 			}
 
-			if len(nodes) > 0 {
-				fmt.Println()
-				printSectionHeader("NODES")
-				headers := []string{"NODE", "STATUS", "ONLINE", "CPU", "MEM USED", "MEM TOTAL", "UPTIME"}
-				rows := make([][]string, 0, len(nodes))
+			if len(members) > 0 {
+				// /cluster/status carries membership only; usage comes from /nodes
+				nodes, err := client.Nodes.List(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				usage := map[string]proxmox.Node{}
 				for _, n := range nodes {
+					usage[n.Node] = n
+				}
+
+				if !output.IsDelimited() {
+					fmt.Println()
+					printSectionHeader("NODES")
+				}
+
+				headers := []string{"NODE", "STATUS", "ONLINE", "CPU", "MEM USED", "MEM TOTAL", "UPTIME"}
+				rows := make([][]string, 0, len(members))
+
+				for _, m := range members {
+					n := usage[m.Name]
+
 					rows = append(rows, []string{
-						toString(n["name"]),
-						nodeStatus(n),
-						formatBool(n["online"]),
-						formatPct(toFloat(n["cpu"])),
-						formatBytes(toFloat(n["mem"])),
-						formatBytes(toFloat(n["maxmem"])),
-						formatUptime(toFloat(n["uptime"])),
+						m.Name,
+						color.Status(nodeStatus(bool(m.Online))),
+						format.Bool(bool(m.Online)),
+						output.Percent(float64(n.CPU)),
+						output.Bytes(float64(n.Mem)),
+						output.Bytes(float64(n.MaxMem)),
+						output.Uptime(int64(n.Uptime)),
 					})
 				}
 
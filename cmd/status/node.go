@@ -17,10 +17,12 @@ package status
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -31,109 +33,81 @@ func nodeStatusCmd() *cobra.Command {
 		Use:   "node",
 		Short: "Show detailed status of a single node",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			if node == "" {
-				node, err = client.DefaultNode()
+				node, err = client.DefaultNode(cmd.Context())
 				if err != nil {
 					return err
 				}
 			}
 
-			var statusResp struct {
-				Data map[string]any `json:"data"`
-			}
-
-			if err := client.Get(fmt.Sprintf("/nodes/%s/status", node), &statusResp); err != nil {
+			d, err := client.Nodes.Status(cmd.Context(), node)
+			if err != nil {
 				return err
 			}
 
-			var versionResp struct {
-				Data map[string]any `json:"data"`
+			// Version details are optional; a failure only blanks that row.
+			v, err := client.Nodes.Version(cmd.Context(), node)
+			if err != nil {
+				v = &proxmox.NodeVersion{}
 			}
 
-			_ = client.Get(fmt.Sprintf("/nodes/%s/version", node), &versionResp)
-
-			if output.IsJSON() {
-				combined := map[string]any{
-					"status":  statusResp.Data,
-					"version": versionResp.Data,
-				}
-
-				return output.JSON(combined)
+			if output.IsStructured() {
+				return output.Print(struct {
+					Status  *proxmox.NodeStatus  `json:"status"`
+					Version *proxmox.NodeVersion `json:"version"`
+				}{d, v})
 			}
 
-			d := statusResp.Data
-			v := versionResp.Data
-
-			fmt.Println()
-			printSectionHeader(fmt.Sprintf("NODE: %s", strings.ToUpper(node)))
-
-			headers := []string{"FIELD", "VALUE"}
 			overviewRows := [][]string{
-				{"Hostname", toString(d["pveversion"])},
-				{"Kernel", toString(d["kversion"])},
-				{"PVE Version", toString(v["version"])},
-				{"Uptime", formatUptime(toFloat(d["uptime"]))},
-				{"Timezone", toString(d["timezone"])},
+				{"Hostname", d.PVEVersion},
+				{"Kernel", d.KVersion},
+				{"PVE Version", v.Version},
+				{"Uptime", output.Uptime(int64(d.Uptime))},
+				{"Timezone", d.Timezone},
 			}
 
-			output.Table(headers, overviewRows)
-
-			cpuInfo, _ := d["cpuinfo"].(map[string]any)
-			fmt.Println()
-			printSectionHeader("CPU")
 			cpuRows := [][]string{
-				{"Model", toString(cpuInfo["model"])},
-				{"Sockets", fmt.Sprintf("%.0f", toFloat(cpuInfo["sockets"]))},
-				{"Cores per Socket", fmt.Sprintf("%.0f", toFloat(cpuInfo["cores"]))},
-				{"Threads (total)", fmt.Sprintf("%.0f", toFloat(cpuInfo["cpus"]))},
-				{"Current Usage", formatPct(toFloat(d["cpu"]))},
-				{"Load Avg (1/5/15m)", formatLoadAvg(d["loadavg"])},
+				{"Model", d.CPUInfo.Model},
+				{"Sockets", strconv.FormatInt(int64(d.CPUInfo.Sockets), 10)},
+				{"Cores per Socket", strconv.FormatInt(int64(d.CPUInfo.Cores), 10)},
+				{"Threads (total)", strconv.FormatInt(int64(d.CPUInfo.CPUs), 10)},
+				{"Current Usage", output.Percent(float64(d.CPU))},
+				{"Load Avg (1/5/15m)", formatLoadAvg(d.LoadAvg)},
 			}
 
-			output.Table(headers, cpuRows)
-
-			memInfo, _ := d["memory"].(map[string]any)
-			fmt.Println()
-			printSectionHeader("MEMORY")
 			memRows := [][]string{
-				{"Used", formatBytes(toFloat(memInfo["used"]))},
-				{"Free", formatBytes(toFloat(memInfo["free"]))},
-				{"Total", formatBytes(toFloat(memInfo["total"]))},
-				{"Usage", formatBar(toFloat(memInfo["used"]), toFloat(memInfo["total"]), 20)},
+				{"Used", output.Bytes(float64(d.Memory.Used))},
+				{"Free", output.Bytes(float64(d.Memory.Free))},
+				{"Total", output.Bytes(float64(d.Memory.Total))},
+				{"Usage", output.Usage(float64(d.Memory.Used), float64(d.Memory.Total), 20)},
 			}
 
-			output.Table(headers, memRows)
-
-			swapInfo, _ := d["swap"].(map[string]any)
-			fmt.Println()
-			printSectionHeader("SWAP")
 			swapRows := [][]string{
-				{"Used", formatBytes(toFloat(swapInfo["used"]))},
-				{"Free", formatBytes(toFloat(swapInfo["free"]))},
-				{"Total", formatBytes(toFloat(swapInfo["total"]))},
-				{"Usage", formatBar(toFloat(swapInfo["used"]), toFloat(swapInfo["total"]), 20)},
+				{"Used", output.Bytes(float64(d.Swap.Used))},
+				{"Free", output.Bytes(float64(d.Swap.Free))},
+				{"Total", output.Bytes(float64(d.Swap.Total))},
+				{"Usage", output.Usage(float64(d.Swap.Used), float64(d.Swap.Total), 20)},
 			}
 
-			output.Table(headers, swapRows)
-
-			rootfsInfo, _ := d["rootfs"].(map[string]any)
-			fmt.Println()
-			printSectionHeader("ROOT FILESYSTEM")
 			rootfsRows := [][]string{
-				{"Used", formatBytes(toFloat(rootfsInfo["used"]))},
-				{"Free", formatBytes(toFloat(rootfsInfo["free"]))},
-				{"Total", formatBytes(toFloat(rootfsInfo["total"]))},
-				{"Usage", formatBar(toFloat(rootfsInfo["used"]), toFloat(rootfsInfo["total"]), 20)},
+				{"Used", output.Bytes(float64(d.RootFS.Used))},
+				{"Free", output.Bytes(float64(d.RootFS.Free))},
+				{"Total", output.Bytes(float64(d.RootFS.Total))},
+				{"Usage", output.Usage(float64(d.RootFS.Used), float64(d.RootFS.Total), 20)},
 			}
 
-			output.Table(headers, rootfsRows)
-
-			fmt.Println()
+			printSections([]section{
+				{fmt.Sprintf("NODE: %s", strings.ToUpper(node)), "", overviewRows},
+				{"CPU", "CPU", cpuRows},
+				{"MEMORY", "Memory", memRows},
+				{"SWAP", "Swap", swapRows},
+				{"ROOT FILESYSTEM", "Root FS", rootfsRows},
+			})
 
 			return nil
 		},
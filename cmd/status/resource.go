@@ -16,11 +16,17 @@ limitations under the License.
 package status
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -28,122 +34,79 @@ func resourcesCmd() *cobra.Command {
 	var resourceType string
 
 	cmd := &cobra.Command{
-		Use:   "resources",
-		Short: "List all cluster resources (VMs, containers, storage, nodes)",
+		Use:         "resources",
+		Short:       "List all cluster resources (VMs, containers, storage, nodes)",
+		Annotations: map[string]string{api.FleetAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
-			if err != nil {
-				return err
-			}
+			results := api.Fleet(cmd.Context(), func(ctx context.Context, c *api.Client) ([]proxmox.ClusterResource, error) {
+				return c.Cluster.Resources(ctx, resourceType)
+			})
 
-			path := "/cluster/resources"
-			if resourceType != "" {
-				path += "?type=" + resourceType
+			if !api.AllContexts() {
+				if err := results[0].Err; err != nil {
+					return err
+				}
 			}
 
-			var resp struct {
-				Data []map[string]interface{} `json:"data"`
-			}
+			resources := make([]contextResource, 0)
 
-			if err := client.Get(path, &resp); err != nil {
-				return err
+			for _, r := range results {
+				if r.Failed() {
+					resources = append(resources, contextResource{Context: r.Context, Error: r.Err.Error()})
+				}
+
+				for _, item := range r.Items {
+					resources = append(resources, contextResource{Context: r.Context, ClusterResource: item})
+				}
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsBare() {
+				if err := output.List(resources, resourceColumns("all")); err != nil {
+					return err
+				}
+
+				return api.FleetErrors(results)
 			}
 
-			grouped := map[string][]map[string]interface{}{}
+			// Groups keep the order the API lists them in; --sort-by orders
+			// the rows inside each group. Sorting once with every type's
+			// columns lets it name a column only some of the groups show.
 			order := []string{}
 
-			for _, r := range resp.Data {
-				t := toString(r["type"])
-				if _, exists := grouped[t]; !exists {
-					order = append(order, t)
+			for _, r := range resources {
+				if !slices.Contains(order, r.Type) {
+					order = append(order, r.Type)
 				}
+			}
+
+			var all []output.Column[contextResource]
+
+			for _, t := range []string{"qemu", "storage", "node", ""} {
+				all = append(all, resourceColumns(t)...)
+			}
+
+			if err := output.Sort(resources, all); err != nil {
+				return err
+			}
+
+			grouped := map[string][]contextResource{}
 
-				grouped[t] = append(grouped[t], r)
+			for _, r := range resources {
+				grouped[r.Type] = append(grouped[r.Type], r)
 			}
 
 			for _, t := range order {
-				items := grouped[t]
 				fmt.Println()
 				printSectionHeader(strings.ToUpper(t) + "S")
 
-				switch t {
-				case "qemu", "lxc":
-					headers := []string{"ID", "NAME", "NODE", "STATUS", "CPU", "MEM", "DISK", "UPTIME"}
-					rows := make([][]string, 0, len(items))
-
-					for _, r := range items {
-						rows = append(rows, []string{
-							fmt.Sprintf("%.0f", toFloat(r["vmid"])),
-							toString(r["name"]),
-							toString(r["node"]),
-							toString(r["status"]),
-							formatPct(toFloat(r["cpu"])),
-							formatBytes(toFloat(r["mem"])),
-							formatBytes(toFloat(r["disk"])),
-							formatUptime(toFloat(r["uptime"])),
-						})
-					}
-
-					output.Table(headers, rows)
-
-				case "storage":
-					headers := []string{"NAME", "NODE", "STATUS", "USED", "TOTAL", "USAGE"}
-					rows := make([][]string, 0, len(items))
-
-					for _, r := range items {
-						rows = append(rows, []string{
-							toString(r["storage"]),
-							toString(r["node"]),
-							toString(r["status"]),
-							formatBytes(toFloat(r["disk"])),
-							formatBytes(toFloat(r["maxdisk"])),
-							formatBar(toFloat(r["disk"]), toFloat(r["maxdisk"]), 16),
-						})
-					}
-
-					output.Table(headers, rows)
-
-				case "node":
-					headers := []string{"NODE", "STATUS", "CPU", "MEM USED", "MEM TOTAL", "UPTIME"}
-					rows := make([][]string, 0, len(items))
-
-					for _, r := range items {
-						rows = append(rows, []string{
-							toString(r["node"]),
-							toString(r["status"]),
-							formatPct(toFloat(r["cpu"])),
-							formatBytes(toFloat(r["mem"])),
-							formatBytes(toFloat(r["maxmem"])),
-							formatUptime(toFloat(r["uptime"])),
-						})
-					}
-
-					output.Table(headers, rows)
-
-				default:
-					headers := []string{"ID", "TYPE", "STATUS", "NODE"}
-					rows := make([][]string, 0, len(items))
-
-					for _, r := range items {
-						rows = append(rows, []string{
-							toString(r["id"]),
-							toString(r["type"]),
-							toString(r["status"]),
-							toString(r["node"]),
-						})
-					}
-
-					output.Table(headers, rows)
+				if err := output.Render(grouped[t], resourceColumns(t)); err != nil {
+					return err
 				}
 			}
 
 			fmt.Println()
 
-			return nil
+			return api.FleetErrors(results)
 		},
 	}
 
@@ -151,3 +114,121 @@ func resourcesCmd() *cobra.Command {
 
 	return cmd
 }
+
+// contextResource tags a resource with the context it came from in
+// --all-contexts mode, or carries the error of a context that failed, as
+// ContextGuest does.
+type contextResource struct {
+	Context string `json:"context,omitempty"`
+	Error   string `json:"error,omitempty"`
+	proxmox.ClusterResource
+}
+
+// MarshalJSON leaves the empty resource fields out of a failed context's row.
+func (r contextResource) MarshalJSON() ([]byte, error) {
+	if r.Error != "" {
+		return json.Marshal(api.FleetError{Context: r.Context, Error: r.Error})
+	}
+
+	type plain contextResource
+
+	return json.Marshal(plain(r))
+}
+
+// resourceColumns lists the table columns for one resource type, or one
+// set covering every type for "all"; CONTEXT leads in --all-contexts mode.
+func resourceColumns(t string) []output.Column[contextResource] {
+	type col = output.Column[contextResource]
+
+	var columns []col
+
+	switch t {
+	case "qemu", "lxc":
+		columns = []col{
+			{Header: "ID", Field: "vmid", Value: func(r contextResource) string { return format.VMID(int64(r.VMID)) }, Key: true},
+			{Header: "NAME", Field: "name", Value: func(r contextResource) string { return r.Name }},
+			{Header: "NODE", Field: "node", Value: func(r contextResource) string { return r.Node }},
+			{Header: "STATUS", Field: "status", Value: func(r contextResource) string { return color.Status(r.Status) }},
+			{Header: "CPU", Field: "cpu", Value: func(r contextResource) string { return output.Percent(float64(r.CPU)) }},
+			{Header: "MEM", Field: "mem", Value: func(r contextResource) string { return output.Bytes(float64(r.Mem)) }},
+			{Header: "DISK", Field: "disk", Value: func(r contextResource) string { return output.Bytes(float64(r.Disk)) }},
+			{Header: "UPTIME", Field: "uptime", Value: func(r contextResource) string { return output.Uptime(int64(r.Uptime)) }},
+			{Header: "POOL", Field: "pool", Value: func(r contextResource) string { return r.Pool }, Wide: true},
+			{Header: "TAGS", Field: "tags", Value: func(r contextResource) string { return format.List(r.Tags) }, Wide: true},
+			{Header: "MAX DISK", Field: "maxdisk", Value: func(r contextResource) string { return output.Bytes(float64(r.MaxDisk)) }, Wide: true},
+		}
+	case "storage":
+		columns = []col{
+			{Header: "NAME", Field: "storage", Value: func(r contextResource) string { return r.Storage }, Key: true},
+			{Header: "NODE", Field: "node", Value: func(r contextResource) string { return r.Node }},
+			{Header: "STATUS", Field: "status", Value: func(r contextResource) string { return color.Status(r.Status) }},
+			{Header: "USED", Field: "disk", Value: func(r contextResource) string { return output.Bytes(float64(r.Disk)) }},
+			{Header: "TOTAL", Field: "maxdisk", Value: func(r contextResource) string { return output.Bytes(float64(r.MaxDisk)) }},
+			{Header: "USAGE", Value: func(r contextResource) string { return output.Usage(float64(r.Disk), float64(r.MaxDisk), 16) }, SortKey: diskUsage},
+			{Header: "SHARED", Field: "shared", Value: func(r contextResource) string { return format.Bool(bool(r.Shared)) }, Wide: true},
+			{Header: "CONTENT", Field: "content", Value: func(r contextResource) string { return format.List(r.Content) }, Wide: true},
+		}
+	case "all":
+		columns = []col{
+			{Header: "ID", Field: "id", Value: func(r contextResource) string { return r.ID }, Key: true},
+			{Header: "TYPE", Field: "type", Value: func(r contextResource) string { return r.Type }},
+			{Header: "NAME", Field: "name", Value: resourceName},
+			{Header: "NODE", Field: "node", Value: func(r contextResource) string { return r.Node }},
+			{Header: "STATUS", Field: "status", Value: func(r contextResource) string { return color.Status(r.Status) }},
+			{Header: "CPU", Field: "cpu", Value: func(r contextResource) string { return output.Percent(float64(r.CPU)) }},
+			{Header: "MEM", Field: "mem", Value: func(r contextResource) string { return output.Bytes(float64(r.Mem)) }},
+			{Header: "MAX MEM", Field: "maxmem", Value: func(r contextResource) string { return output.Bytes(float64(r.MaxMem)) }},
+			{Header: "DISK", Field: "disk", Value: func(r contextResource) string { return output.Bytes(float64(r.Disk)) }},
+			{Header: "MAX DISK", Field: "maxdisk", Value: func(r contextResource) string { return output.Bytes(float64(r.MaxDisk)) }},
+			{Header: "USAGE", Value: func(r contextResource) string { return output.Usage(float64(r.Disk), float64(r.MaxDisk), 16) }, SortKey: diskUsage},
+			{Header: "UPTIME", Field: "uptime", Value: func(r contextResource) string { return output.Uptime(int64(r.Uptime)) }},
+		}
+	case "node":
+		columns = []col{
+			{Header: "NODE", Field: "node", Value: func(r contextResource) string { return r.Node }, Key: true},
+			{Header: "STATUS", Field: "status", Value: func(r contextResource) string { return color.Status(r.Status) }},
+			{Header: "CPU", Field: "cpu", Value: func(r contextResource) string { return output.Percent(float64(r.CPU)) }},
+			{Header: "MEM USED", Field: "mem", Value: func(r contextResource) string { return output.Bytes(float64(r.Mem)) }},
+			{Header: "MEM TOTAL", Field: "maxmem", Value: func(r contextResource) string { return output.Bytes(float64(r.MaxMem)) }},
+			{Header: "UPTIME", Field: "uptime", Value: func(r contextResource) string { return output.Uptime(int64(r.Uptime)) }},
+			{Header: "CPUS", Field: "maxcpu", Value: func(r contextResource) string { return fmt.Sprintf("%.0f", float64(r.MaxCPU)) }, Wide: true},
+			{Header: "DISK", Field: "disk", Value: func(r contextResource) string { return output.Bytes(float64(r.Disk)) }, Wide: true},
+		}
+	default:
+		columns = []col{
+			{Header: "ID", Field: "id", Value: func(r contextResource) string { return r.ID }, Key: true},
+			{Header: "TYPE", Field: "type", Value: func(r contextResource) string { return r.Type }},
+			{Header: "STATUS", Field: "status", Value: func(r contextResource) string { return color.Status(r.Status) }},
+			{Header: "NODE", Field: "node", Value: func(r contextResource) string { return r.Node }},
+		}
+	}
+
+	if api.AllContexts() {
+		columns = append([]col{
+			{Header: "CONTEXT", Field: "context", Value: func(r contextResource) string { return r.Context }},
+		}, columns...)
+	}
+
+	return columns
+}
+
+// resourceName is the guest name, storage id, or node name of a resource.
+func resourceName(r contextResource) string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case r.Storage != "":
+		return r.Storage
+	}
+
+	return r.Node
+}
+
+// diskUsage is the used fraction of a resource's disk, for sorting by USAGE.
+func diskUsage(r contextResource) any {
+	if r.MaxDisk == 0 {
+		return nil
+	}
+
+	return float64(r.Disk) / float64(r.MaxDisk)
+}
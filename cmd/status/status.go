@@ -17,10 +17,13 @@ package status
 
 import (
 	"fmt"
-	"math"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/araddon/dateparse"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -59,150 +62,104 @@ func printSectionHeader(title string) {
 	fmt.Println("  " + strings.Repeat("─", len(title)+2))
 }
 
-func toString(v any) string {
-	if v == nil {
-		return ""
-	}
-
-	return fmt.Sprintf("%v", v)
+// section is one titled FIELD/VALUE table of a status report.
+type section struct {
+	title  string
+	prefix string // qualifies field names when sections are merged
+	rows   [][]string
 }
 
-func toFloat(v any) float64 {
-	if v == nil {
-		return 0
-	}
-
-	if val, ok := v.(float64); ok {
-		return val
-	}
-
-	return 0
-}
+// printSections prints each section under its title. CSV and TSV get one
+// FIELD/VALUE table instead, with field names qualified by their section.
+func printSections(sections []section) {
+	headers := []string{"FIELD", "VALUE"}
 
-func formatBool(v any) string {
-	switch val := v.(type) {
-	case bool:
-		if val {
-			return "yes"
-		}
+	if output.IsDelimited() {
+		var rows [][]string
 
-		return "no"
-	case float64:
-		if val == 1 {
-			return "yes"
+		for _, s := range sections {
+			for _, r := range s.rows {
+				rows = append(rows, []string{strings.TrimSpace(s.prefix + " " + r[0]), r[1]})
+			}
 		}
 
-		return "no"
-	}
-
-	return "no"
-}
-
-func formatPct(v float64) string {
-	return fmt.Sprintf("%.1f%%", v*100)
-}
-
-func formatBytes(b float64) string {
-	if b == 0 {
-		return "0 B"
-	}
+		output.Table(headers, rows)
 
-	const unit = 1024.0
-
-	if b < unit {
-		return fmt.Sprintf("%.0f B", b)
+		return
 	}
 
-	div, exp := unit, 0
-
-	for n := b / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
+	for _, s := range sections {
+		fmt.Println()
+		printSectionHeader(s.title)
+		output.Table(headers, s.rows)
 	}
 
-	return fmt.Sprintf("%.1f %cB", b/div, "KMGTPE"[exp])
+	fmt.Println()
 }
 
-func formatBar(used, total float64, width int) string {
-	if total == 0 {
+func formatLoadAvg(load []proxmox.Float) string {
+	if len(load) < 3 {
 		return "n/a"
 	}
 
-	pct := used / total
-	filled := min(int(math.Round(pct*float64(width))), width)
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
-
-	return fmt.Sprintf("[%s] %.1f%%", bar, pct*100)
+	return fmt.Sprintf("%.2f / %.2f / %.2f", load[0], load[1], load[2])
 }
 
-func formatUptime(seconds float64) string {
-	if seconds == 0 {
-		return "—"
-	}
-
-	d := time.Duration(seconds) * time.Second
-	days := int(d.Hours()) / 24
-	hours := int(d.Hours()) % 24
-	minutes := int(d.Minutes()) % 60
-
-	if days > 0 {
-		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
-	}
-
-	if hours > 0 {
-		return fmt.Sprintf("%dh %dm", hours, minutes)
+func nodeStatus(online bool) string {
+	if online {
+		return "online"
 	}
 
-	return fmt.Sprintf("%dm", minutes)
+	return "offline"
 }
 
-func formatEpoch(v any) string {
-	if v == nil {
-		return ""
-	}
-
-	if val, ok := v.(float64); ok && val > 0 {
-		return time.Unix(int64(val), 0).Format("2006-01-02 15:04")
+// yearless lists the month-and-day layouts accepted without a year; they
+// are matched against lower-cased input and fall in the current year.
+var yearless = []string{"jan 2 3pm", "jan 2 3:04pm", "jan 2 15:04", "jan 2"}
+
+// parseWhen accepts absolute dates, relative durations ("90m", "12h", "7d")
+// counted back from now, and the keywords now, today, and yesterday.
+func parseWhen(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	y, m, d := now.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+
+	switch strings.ToLower(s) {
+	case "now":
+		return now, nil
+	case "today":
+		return midnight, nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), nil
+	}
+
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil {
+			return now.AddDate(0, 0, -n), nil
+		}
 	}
 
-	return ""
-}
-
-func formatLoadAvg(v any) string {
-	arr, ok := v.([]any)
-	if !ok || len(arr) < 3 {
-		return "n/a"
+	if dur, err := time.ParseDuration(s); err == nil {
+		return now.Add(-dur), nil
 	}
 
-	return fmt.Sprintf("%.2f / %.2f / %.2f",
-		toFloat(arr[0]), toFloat(arr[1]), toFloat(arr[2]))
-}
-
-func nodeStatus(n map[string]any) string {
-	if toFloat(n["online"]) == 1 || n["online"] == true {
-		return "online"
+	for _, layout := range yearless {
+		if t, err := time.ParseInLocation(layout, strings.ToLower(s), time.Local); err == nil {
+			return time.Date(y, t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
+		}
 	}
 
-	return "offline"
+	return dateparse.ParseLocal(s)
 }
 
-func taskStatus(t map[string]any) string {
-	status := toString(t["status"])
-	if status == "" {
-		if toString(t["endtime"]) == "" {
+func taskStatus(t proxmox.Task) string {
+	if t.Status == "" {
+		if t.Running() {
 			return "running"
 		}
 
 		return "unknown"
 	}
 
-	return status
-}
-
-func truncate(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-
-	return s[:max-1] + "…"
+	return t.Status
 }
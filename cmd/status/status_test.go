@@ -0,0 +1,65 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package status
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWhen(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 14, 30, 0, 0, time.Local)
+	midnight := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.Local)
+
+	// Every form listed in the tasks --since/--until help.
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2024-01-15 22:00", time.Date(2024, time.January, 15, 22, 0, 0, 0, time.Local)},
+		{"Jan 15 10pm", time.Date(2025, time.January, 15, 22, 0, 0, 0, time.Local)},
+		{"jan 15 10:30PM", time.Date(2025, time.January, 15, 22, 30, 0, 0, time.Local)},
+		{"Jan 15 22:00", time.Date(2025, time.January, 15, 22, 0, 0, 0, time.Local)},
+		{"Jan 15", time.Date(2025, time.January, 15, 0, 0, 0, 0, time.Local)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"12h", now.Add(-12 * time.Hour)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"now", now},
+		{"today", midnight},
+		{"yesterday", midnight.AddDate(0, 0, -1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseWhen(tt.in, now)
+			if err != nil {
+				t.Fatalf("parseWhen(%q): %v", tt.in, err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("parseWhen(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWhenInvalid(t *testing.T) {
+	for _, in := range []string{"", "soon", "Jan 45 10pm", "12x"} {
+		if got, err := parseWhen(in, time.Now()); err == nil {
+			t.Errorf("parseWhen(%q) = %v, want error", in, got)
+		}
+	}
+}
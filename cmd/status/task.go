@@ -16,85 +16,295 @@ limitations under the License.
 package status
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
+type taskFilter struct {
+	taskType string
+	vmid     string
+	user     string
+	source   string
+	errors   bool
+	since    time.Time
+	until    time.Time
+}
+
 func tasksCmd() *cobra.Command {
 	var (
 		node   string
 		limit  int
-		errors bool
+		since  string
+		until  string
+		filter taskFilter
 	)
 
 	cmd := &cobra.Command{
 		Use:   "tasks",
 		Short: "Show recent tasks on a node or across the cluster",
+		Long: `Show task history across the whole cluster, or on a single node with --node.
+
+Cluster-wide mode merges /cluster/tasks with a query against every online
+node, removes duplicates, and sorts the result by start time (newest first).
+A node that cannot be queried is reported on stderr and skipped.
+
+--since and --until accept absolute dates ("2024-01-15 22:00", "Jan 15 10pm";
+a date without a year falls in the current year), relative durations ("90m", "12h", "7d"), and "now", "today", or "yesterday".
+
+Examples:
+  # What touched VM 210 last night?
+  proxmoxctl status tasks --vmid 210 --since yesterday
+
+  # Failed backups this week
+  proxmoxctl status tasks --type vzdump --errors --since 7d
+
+  # Currently running tasks on one node
+  proxmoxctl status tasks --node pve2 --source active`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
-			if err != nil {
-				return err
+			var err error
+
+			now := time.Now()
+
+			switch filter.source {
+			case "active", "archive", "all":
+			default:
+				return fmt.Errorf("invalid --source %q — use active, archive, or all", filter.source)
 			}
 
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
+			if since != "" {
+				if filter.since, err = parseWhen(since, now); err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
 				}
 			}
 
-			path := fmt.Sprintf("/nodes/%s/tasks?limit=%d", node, limit)
-			if errors {
-				path += "&errors=1"
+			if until != "" {
+				if filter.until, err = parseWhen(until, now); err != nil {
+					return fmt.Errorf("invalid --until: %w", err)
+				}
 			}
 
-			var resp struct {
-				Data []map[string]any `json:"data"`
+			client, err := api.New(cmd.Context())
+			if err != nil {
+				return err
 			}
 
-			if err := client.Get(path, &resp); err != nil {
+			var tasks []proxmox.Task
+
+			if node != "" {
+				tasks, err = nodeTasks(cmd.Context(), client, node, filter, limit)
+			} else {
+				tasks, err = clusterTasks(cmd.Context(), client, filter, limit)
+			}
+
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			sort.SliceStable(tasks, func(i, j int) bool {
+				return tasks[i].StartTime > tasks[j].StartTime
+			})
+
+			if limit > 0 && len(tasks) > limit {
+				tasks = tasks[:limit]
+			}
+
+			columns := []output.Column[proxmox.Task]{
+				{Header: "STARTED", Field: "starttime", Value: func(t proxmox.Task) string { return format.Epoch(int64(t.StartTime)) }},
+				{Header: "ENDED", Field: "endtime", Value: func(t proxmox.Task) string { return format.Epoch(int64(t.EndTime)) }},
+				{Header: "NODE", Field: "node", Value: func(t proxmox.Task) string { return t.Node }},
+				{Header: "TYPE", Field: "type", Value: func(t proxmox.Task) string { return t.Type }},
+				{Header: "ID", Field: "id", Value: func(t proxmox.Task) string { return string(t.ID) }},
+				{Header: "USER", Field: "user", Value: func(t proxmox.Task) string { return t.User }},
+				{Header: "STATUS", Field: "status", Value: func(t proxmox.Task) string { return color.Status(taskStatus(t)) }},
+				{Header: "UPID", Field: "upid", Value: func(t proxmox.Task) string { return t.UPID }, Key: true},
 			}
 
-			if len(resp.Data) == 0 {
+			if output.IsBare() {
+				return output.List(tasks, columns)
+			}
+
+			if len(tasks) == 0 {
 				fmt.Println("No tasks found.")
 				return nil
 			}
 
+			title := "RECENT TASKS — CLUSTER"
+			if node != "" {
+				title = fmt.Sprintf("RECENT TASKS — NODE: %s", strings.ToUpper(node))
+			}
+
 			fmt.Println()
-			printSectionHeader(fmt.Sprintf("RECENT TASKS — NODE: %s", strings.ToUpper(node)))
-			headers := []string{"UPID", "TYPE", "USER", "STATUS", "STARTED", "ENDED"}
-			rows := make([][]string, 0, len(resp.Data))
-
-			for _, t := range resp.Data {
-				rows = append(rows, []string{
-					truncate(toString(t["upid"]), 40),
-					toString(t["type"]),
-					toString(t["user"]),
-					taskStatus(t),
-					formatEpoch(t["starttime"]),
-					formatEpoch(t["endtime"]),
-				})
+			printSectionHeader(title)
+
+			if err := output.List(tasks, columns); err != nil {
+				return err
 			}
 
-			output.Table(headers, rows)
 			fmt.Println()
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&node, "node", "", "Node name (auto-detected if not set)")
+	cmd.Flags().StringVar(&node, "node", "", "Only show tasks from this node (default: all nodes)")
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of tasks to show")
-	cmd.Flags().BoolVar(&errors, "errors", false, "Show only failed tasks")
+	cmd.Flags().BoolVar(&filter.errors, "errors", false, "Show only failed tasks")
+	cmd.Flags().StringVar(&filter.taskType, "type", "", "Filter by task type (e.g. vzdump, qmstart, qmigrate)")
+	cmd.Flags().StringVar(&filter.vmid, "vmid", "", "Filter by guest VMID")
+	cmd.Flags().StringVar(&filter.user, "user", "", "Filter by user (substring match, e.g. root@pam)")
+	cmd.Flags().StringVar(&since, "since", "", "Only tasks started at or after this time")
+	cmd.Flags().StringVar(&until, "until", "", "Only tasks started at or before this time")
+	cmd.Flags().StringVar(&filter.source, "source", "all", "Task source: active, archive, or all")
 
 	return cmd
 }
+
+func clusterTasks(ctx context.Context, client *api.Client, filter taskFilter, limit int) ([]proxmox.Task, error) {
+	nodes, err := client.Nodes.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	tasks := []proxmox.Task{}
+
+	add := func(list []proxmox.Task) {
+		for _, t := range list {
+			upid := t.UPID
+			if seen[upid] || !filter.match(t) {
+				continue
+			}
+
+			seen[upid] = true
+			tasks = append(tasks, t)
+		}
+	}
+
+	var (
+		lastErr  error
+		sources  int
+		failures int
+	)
+
+	for _, n := range nodes {
+		name := n.Node
+
+		if n.Status != "online" {
+			fmt.Fprintln(os.Stderr, color.Warn("Skipping offline node: "+name))
+			continue
+		}
+
+		sources++
+
+		list, err := nodeTasks(ctx, client, name, filter, limit)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, color.Warn(fmt.Sprintf("Skipping node %s: %v", name, err)))
+			lastErr = err
+			failures++
+
+			continue
+		}
+
+		add(list)
+	}
+
+	sources++
+
+	recent, err := client.Cluster.Tasks(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, color.Warn(fmt.Sprintf("Skipping cluster task log: %v", err)))
+		lastErr = err
+		failures++
+	} else {
+		add(recent)
+	}
+
+	// Only give up when no source answered at all.
+	if failures == sources {
+		return nil, lastErr
+	}
+
+	return tasks, nil
+}
+
+func nodeTasks(ctx context.Context, client *api.Client, node string, filter taskFilter, limit int) ([]proxmox.Task, error) {
+	list, err := client.Tasks.List(ctx, node, proxmox.TaskListOptions{
+		Source:     filter.source,
+		Limit:      limit,
+		Errors:     filter.errors,
+		TypeFilter: filter.taskType,
+		VMID:       filter.vmid,
+		UserFilter: filter.user,
+		Since:      filter.since,
+		Until:      filter.until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]proxmox.Task, 0, len(list))
+
+	for _, t := range list {
+		// Older nodes ignore some filters, so apply them again locally.
+		if filter.match(t) {
+			tasks = append(tasks, t)
+		}
+	}
+
+	return tasks, nil
+}
+
+// match applies the filters client-side; /cluster/tasks accepts none of them.
+func (f taskFilter) match(t proxmox.Task) bool {
+	if f.taskType != "" && t.Type != f.taskType {
+		return false
+	}
+
+	if f.vmid != "" && string(t.ID) != f.vmid {
+		return false
+	}
+
+	if f.user != "" && !strings.Contains(t.User, f.user) {
+		return false
+	}
+
+	running := t.Running()
+
+	switch f.source {
+	case "active":
+		if !running {
+			return false
+		}
+	case "archive":
+		if running {
+			return false
+		}
+	}
+
+	if f.errors && (running || t.Status == "OK") {
+		return false
+	}
+
+	start := time.Unix(int64(t.StartTime), 0)
+
+	if !f.since.IsZero() && start.Before(f.since) {
+		return false
+	}
+
+	if !f.until.IsZero() && start.After(f.until) {
+		return false
+	}
+
+	return true
+}
@@ -20,7 +20,9 @@ import (
 	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -36,47 +38,43 @@ func contentCmd() *cobra.Command {
 		Short: "List the contents of a storage pool",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			if node == "" {
 				var err error
-				node, err = client.DefaultNode()
+				node, err = client.DefaultNode(cmd.Context())
 				if err != nil {
 					return err
 				}
 			}
 
-			path := fmt.Sprintf("/nodes/%s/storage/%s/content", node, args[0])
-			params := []string{}
-
-			if contentType != "" {
-				params = append(params, "content="+contentType)
-			}
-
-			if vmid != "" {
-				params = append(params, "vmid="+vmid)
-			}
-
-			if len(params) > 0 {
-				path += "?" + strings.Join(params, "&")
-			}
-
-			var resp struct {
-				Data []map[string]any `json:"data"`
+			items, err := client.Storage.Content(cmd.Context(), node, args[0], proxmox.ContentOptions{
+				Content: contentType,
+				VMID:    vmid,
+			})
+			if err != nil {
+				return err
 			}
 
-			if err := client.Get(path, &resp); err != nil {
-				return err
+			columns := []output.Column[proxmox.StorageContent]{
+				{Header: "VOLUME ID", Field: "volid", Value: func(c proxmox.StorageContent) string { return c.VolID }, Key: true},
+				{Header: "TYPE", Field: "content", Value: func(c proxmox.StorageContent) string { return c.Content }},
+				{Header: "FORMAT", Field: "format", Value: func(c proxmox.StorageContent) string { return c.Format }},
+				{Header: "SIZE", Field: "size", Value: func(c proxmox.StorageContent) string { return output.Bytes(float64(c.Size)) }},
+				{Header: "VMID", Field: "vmid", Value: func(c proxmox.StorageContent) string { return format.VMID(int64(c.VMID)) }},
+				{Header: "NOTES", Field: "notes", Value: func(c proxmox.StorageContent) string { return c.Notes }},
+				{Header: "CREATED", Field: "ctime", Value: func(c proxmox.StorageContent) string { return format.Epoch(int64(c.CTime)) }, Wide: true},
+				{Header: "PROTECTED", Field: "protected", Value: func(c proxmox.StorageContent) string { return format.Bool(bool(c.Protected)) }, Wide: true},
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsBare() {
+				return output.List(items, columns)
 			}
 
-			if len(resp.Data) == 0 {
+			if len(items) == 0 {
 				fmt.Printf("No content found in storage '%s'.\n", args[0])
 				return nil
 			}
@@ -84,21 +82,10 @@ func contentCmd() *cobra.Command {
 			fmt.Printf("\n  CONTENT — %s\n", strings.ToUpper(args[0]))
 			fmt.Println("  " + strings.Repeat("─", 72))
 
-			headers := []string{"VOLUME ID", "TYPE", "FORMAT", "SIZE", "VMID", "NOTES"}
-			rows := make([][]string, 0, len(resp.Data))
-			for _, item := range resp.Data {
-				rows = append(rows, []string{
-					toString(item["volid"]),
-					toString(item["content"]),
-					toString(item["format"]),
-					formatBytes(toFloat(item["size"])),
-					formatVMID(item["vmid"]),
-					toString(item["notes"]),
-				})
+			if err := output.List(items, columns); err != nil {
+				return err
 			}
 
-			output.Table(headers, rows)
-
 			fmt.Println()
 
 			return nil
@@ -17,10 +17,13 @@ package storage
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -35,94 +38,96 @@ func listCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List storage pools",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var path string
-
 			if node != "" {
-				path = fmt.Sprintf("/nodes/%s/storage", node)
-			} else {
-				path = "/storage"
-			}
-
-			if active {
-				path += "?enabled=1"
-			}
-
-			if content != "" {
-				sep := "?"
-				if strings.Contains(path, "?") {
-					sep = "&"
+				storages, err := client.Storage.NodeList(cmd.Context(), node, proxmox.StorageListOptions{
+					Content: content,
+					Enabled: active,
+				})
+				if err != nil {
+					return err
 				}
 
-				path += sep + "content=" + content
-			}
-
-			var resp struct {
-				Data []map[string]any `json:"data"`
-			}
-
-			if err := client.Get(path, &resp); err != nil {
-				return err
-			}
+				columns := []output.Column[proxmox.StorageStatus]{
+					{Header: "NAME", Field: "storage", Value: func(s proxmox.StorageStatus) string { return s.Storage }, Key: true},
+					{Header: "TYPE", Field: "type", Value: func(s proxmox.StorageStatus) string { return s.Type }},
+					{Header: "STATUS", Value: storageState},
+					{Header: "USED", Field: "used", Value: func(s proxmox.StorageStatus) string { return output.Bytes(float64(s.Used)) }},
+					{Header: "AVAIL", Field: "avail", Value: func(s proxmox.StorageStatus) string { return output.Bytes(float64(s.Avail)) }},
+					{Header: "TOTAL", Field: "total", Value: func(s proxmox.StorageStatus) string { return output.Bytes(float64(s.Total)) }},
+					{Header: "USAGE", Field: "used_fraction", Value: func(s proxmox.StorageStatus) string {
+						return output.Usage(float64(s.Used), float64(s.Total), 14)
+					}},
+					{Header: "CONTENT", Field: "content", Value: func(s proxmox.StorageStatus) string { return format.List(s.Content) }},
+					{Header: "SHARED", Field: "shared", Value: func(s proxmox.StorageStatus) string { return format.Bool(bool(s.Shared)) }, Wide: true},
+				}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
-			}
+				if output.IsBare() {
+					return output.List(storages, columns)
+				}
 
-			if len(resp.Data) == 0 {
-				fmt.Println("No storage pools found.")
-				return nil
-			}
+				if len(storages) == 0 {
+					fmt.Println("No storage pools found.")
+					return nil
+				}
 
-			if node != "" {
 				fmt.Printf("\n  STORAGE — NODE: %s\n", strings.ToUpper(node))
 				fmt.Println("  " + strings.Repeat("─", 70))
 
-				headers := []string{"NAME", "TYPE", "STATUS", "USED", "AVAIL", "TOTAL", "USAGE", "CONTENT"}
-				rows := make([][]string, 0, len(resp.Data))
-
-				for _, s := range resp.Data {
-					rows = append(rows, []string{
-						toString(s["storage"]),
-						toString(s["type"]),
-						toString(s["status"]),
-						formatBytes(toFloat(s["used"])),
-						formatBytes(toFloat(s["avail"])),
-						formatBytes(toFloat(s["total"])),
-						formatBar(toFloat(s["used"]), toFloat(s["total"]), 14),
-						formatContent(s["content"]),
-					})
+				if err := output.List(storages, columns); err != nil {
+					return err
 				}
-
-				output.Table(headers, rows)
 			} else {
-				fmt.Println("\n  STORAGE — CLUSTER CONFIG")
-				fmt.Println("  " + strings.Repeat("─", 60))
+				configs, err := client.Storage.List(cmd.Context())
+				if err != nil {
+					return err
+				}
 
-				headers := []string{"NAME", "TYPE", "SHARED", "ENABLED", "CONTENT", "PATH / SERVER"}
-				rows := make([][]string, 0, len(resp.Data))
+				// /storage takes no filters, so apply them here.
+				storages := configs[:0]
 
-				for _, s := range resp.Data {
-					location := toString(s["path"])
-					if location == "" {
-						location = toString(s["server"])
+				for _, s := range configs {
+					if active && bool(s.Disable) {
+						continue
 					}
 
-					rows = append(rows, []string{
-						toString(s["storage"]),
-						toString(s["type"]),
-						formatBool(s["shared"]),
-						formatEnabled(s["disable"]),
-						formatContent(s["content"]),
-						location,
-					})
+					if content != "" && !slices.Contains(s.Content, content) {
+						continue
+					}
+
+					storages = append(storages, s)
+				}
+
+				columns := []output.Column[proxmox.StorageConfig]{
+					{Header: "NAME", Field: "storage", Value: func(s proxmox.StorageConfig) string { return s.Storage }, Key: true},
+					{Header: "TYPE", Field: "type", Value: func(s proxmox.StorageConfig) string { return s.Type }},
+					{Header: "SHARED", Field: "shared", Value: func(s proxmox.StorageConfig) string { return format.Bool(bool(s.Shared)) }},
+					{Header: "ENABLED", Field: "disable", Value: func(s proxmox.StorageConfig) string { return format.Bool(!bool(s.Disable)) }},
+					{Header: "CONTENT", Field: "content", Value: func(s proxmox.StorageConfig) string { return format.List(s.Content) }},
+					{Header: "PATH / SERVER", Value: storageLocation},
+					{Header: "NODES", Field: "nodes", Value: func(s proxmox.StorageConfig) string { return format.List(s.Nodes) }, Wide: true},
+					{Header: "POOL", Field: "pool", Value: func(s proxmox.StorageConfig) string { return s.Pool }, Wide: true},
 				}
 
-				output.Table(headers, rows)
+				if output.IsBare() {
+					return output.List(storages, columns)
+				}
+
+				if len(storages) == 0 {
+					fmt.Println("No storage pools found.")
+					return nil
+				}
+
+				fmt.Println("\n  STORAGE — CLUSTER CONFIG")
+				fmt.Println("  " + strings.Repeat("─", 60))
+
+				if err := output.List(storages, columns); err != nil {
+					return err
+				}
 			}
 
 			fmt.Println()
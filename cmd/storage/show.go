@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -32,75 +33,75 @@ func showCmd() *cobra.Command {
 		Short: "Show detailed configuration of a storage pool",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data map[string]any `json:"data"`
-			}
-
-			var path string
-			if node != "" {
-				// Node-level detail (includes usage)
-				path = fmt.Sprintf("/nodes/%s/storage/%s/status", node, args[0])
-			} else {
-				// Cluster config detail
-				path = fmt.Sprintf("/storage/%s", args[0])
-			}
-
-			if err := client.Get(path, &resp); err != nil {
+			cfg, err := client.Storage.Get(cmd.Context(), args[0])
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
-			}
+			detail := storageDetail{StorageConfig: *cfg}
 
-			d := resp.Data
-
-			fmt.Printf("\n  STORAGE: %s\n", strings.ToUpper(args[0]))
-			fmt.Println("  " + strings.Repeat("─", 40))
+			if node != "" {
+				// Node-level detail adds live usage
+				status, err := client.Storage.Status(cmd.Context(), node, args[0])
+				if err != nil {
+					return err
+				}
 
-			rows := [][]string{
-				{"Name", toString(d["storage"])},
-				{"Type", toString(d["type"])},
-				{"Content", formatContent(d["content"])},
-				{"Shared", formatBool(d["shared"])},
-				{"Enabled", formatEnabled(d["disable"])},
+				detail.Usage = status
 			}
 
-			if toString(d["path"]) != "" {
-				rows = append(rows, []string{"Path", toString(d["path"])})
+			if output.IsStructured() {
+				return output.Print(detail)
 			}
 
-			if toString(d["server"]) != "" {
-				rows = append(rows, []string{"Server", toString(d["server"])})
+			d := detail.StorageConfig
+
+			if !output.IsDelimited() {
+				fmt.Printf("\n  STORAGE: %s\n", strings.ToUpper(args[0]))
+				fmt.Println("  " + strings.Repeat("─", 40))
 			}
 
-			if toString(d["export"]) != "" {
-				rows = append(rows, []string{"Export", toString(d["export"])})
+			rows := [][]string{
+				{"Name", d.Storage},
+				{"Type", d.Type},
+				{"Content", format.List(d.Content)},
+				{"Shared", format.Bool(bool(d.Shared))},
+				{"Enabled", format.Bool(!bool(d.Disable))},
 			}
 
-			if toString(d["pool"]) != "" {
-				rows = append(rows, []string{"Pool", toString(d["pool"])})
+			optional := [][]string{
+				{"Path", d.Path},
+				{"Server", d.Server},
+				{"Export", d.Export},
+				{"Pool", d.Pool},
+				{"Datastore", d.Datastore},
+				{"Nodes", format.List(d.Nodes)},
 			}
 
-			if toString(d["datastore"]) != "" {
-				rows = append(rows, []string{"Datastore", toString(d["datastore"])})
+			for _, row := range optional {
+				if row[1] != "" {
+					rows = append(rows, row)
+				}
 			}
 
-			if toFloat(d["total"]) > 0 {
-				rows = append(rows, []string{"Used", formatBytes(toFloat(d["used"]))})
-				rows = append(rows, []string{"Avail", formatBytes(toFloat(d["avail"]))})
-				rows = append(rows, []string{"Total", formatBytes(toFloat(d["total"]))})
-				rows = append(rows, []string{"Usage", formatBar(toFloat(d["used"]), toFloat(d["total"]), 20)})
+			if u := detail.Usage; u != nil && u.Total > 0 {
+				rows = append(rows, []string{"Status", storageState(*u)})
+				rows = append(rows, []string{"Used", output.Bytes(float64(u.Used))})
+				rows = append(rows, []string{"Avail", output.Bytes(float64(u.Avail))})
+				rows = append(rows, []string{"Total", output.Bytes(float64(u.Total))})
+				rows = append(rows, []string{"Usage", output.Usage(float64(u.Used), float64(u.Total), 20)})
 			}
 
 			output.Table([]string{"FIELD", "VALUE"}, rows)
 
-			fmt.Println()
+			if !output.IsDelimited() {
+				fmt.Println()
+			}
 
 			return nil
 		},
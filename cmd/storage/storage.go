@@ -16,10 +16,7 @@ limitations under the License.
 package storage
 
 import (
-	"fmt"
-	"math"
-	"strings"
-
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -56,112 +53,30 @@ Examples:
 	return cmd
 }
 
-func formatBar(used, total float64, width int) string {
-	if total == 0 {
-		return "n/a"
-	}
-
-	pct := used / total
-	filled := min(int(math.Round(pct*float64(width))), width)
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
-
-	return fmt.Sprintf("[%s] %.1f%%", bar, pct*100)
-}
-
-func formatBool(v any) string {
-	switch val := v.(type) {
-	case bool:
-		if val {
-			return "yes"
-		}
-
-		return "no"
-	case float64:
-		if val == 1 {
-			return "yes"
-		}
-
-		return "no"
-	}
-
-	return "no"
-}
-
-func formatBytes(b float64) string {
-	if b == 0 {
-		return "0 B"
-	}
-
-	const unit = 1024.0
-	if b < unit {
-		return fmt.Sprintf("%.0f B", b)
-	}
-
-	div, exp := unit, 0
-
-	for n := b / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-
-	return fmt.Sprintf("%.1f %cB", b/div, "KMGTPE"[exp])
-}
-
-func formatContent(v any) string {
-	if v == nil {
-		return ""
-	}
-
-	return strings.ReplaceAll(toString(v), ",", ", ")
-}
-
-func formatEnabled(v any) string {
-	switch val := v.(type) {
-	case bool:
-		if val {
-			return "no"
-		}
-
-		return "yes"
-	case float64:
-		if val == 1 {
-			return "no"
-		}
-
-		return "yes"
-	}
-
-	return "yes"
-}
-
-func formatVMID(v any) string {
-	if v == nil {
-		return "—"
-	}
-
-	if val, ok := v.(float64); ok {
-		if val == 0 {
-			return "—"
-		}
-
-		return fmt.Sprintf("%.0f", val)
-	}
-
-	return toString(v)
+// storageDetail is the cluster definition of a storage plus, when a node is
+// given, that node's live view of it.
+type storageDetail struct {
+	proxmox.StorageConfig
+	Usage *proxmox.StorageStatus `json:"usage,omitempty"`
 }
 
-func toFloat(v any) float64 {
-	if val, ok := v.(float64); ok {
-		return val
+func storageState(s proxmox.StorageStatus) string {
+	switch {
+	case !bool(s.Enabled):
+		return "disabled"
+	case !bool(s.Active):
+		return "inactive"
 	}
 
-	return 0
+	return "active"
 }
 
-func toString(v any) string {
-	if v == nil {
-		return ""
+// storageLocation is the directory path of a storage, or its server for
+// network storage.
+func storageLocation(s proxmox.StorageConfig) string {
+	if s.Path != "" {
+		return s.Path
 	}
 
-	return fmt.Sprintf("%v", v)
+	return s.Server
 }
@@ -0,0 +1,82 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package task
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/cobra"
+)
+
+func logCmd() *cobra.Command {
+	var follow bool
+
+	cmd := &cobra.Command{
+		Use:   "log <upid>",
+		Short: "Print a task's log, optionally following it until the task ends",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			upid, err := proxmox.ParseUPID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := api.New(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if output.IsStructured() {
+				if follow {
+					if _, err := client.WaitForTask(cmd.Context(), upid.Node, upid.Raw, nil); err != nil {
+						return err
+					}
+				}
+
+				lines, err := client.Tasks.FullLog(cmd.Context(), upid.Node, upid.Raw)
+				if err != nil {
+					return err
+				}
+
+				return output.Print(lines)
+			}
+
+			if !follow {
+				_, err := client.Tasks.WriteLog(cmd.Context(), upid.Node, upid.Raw, 0, os.Stdout)
+				return err
+			}
+
+			status, err := client.WaitForTask(cmd.Context(), upid.Node, upid.Raw, os.Stdout)
+			if err != nil {
+				return err
+			}
+
+			if !status.Succeeded() {
+				return fmt.Errorf("task %s failed: %s", upid.Raw, status.ExitStatus)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Stream new log lines until the task ends")
+
+	return cmd
+}
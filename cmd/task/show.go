@@ -0,0 +1,143 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/cobra"
+)
+
+func showCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <upid>",
+		Short: "Show a task's decoded UPID, exit status, and duration",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			upid, err := proxmox.ParseUPID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := api.New(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			status, err := client.Tasks.Status(cmd.Context(), upid.Node, upid.Raw)
+			if err != nil {
+				return err
+			}
+
+			var ended time.Time
+
+			if status.Running() {
+				ended = time.Now()
+			} else {
+				ended = taskEndTime(cmd.Context(), client, upid)
+			}
+
+			var duration time.Duration
+
+			if !ended.IsZero() {
+				duration = ended.Sub(upid.StartTime)
+			}
+
+			if output.IsStructured() {
+				data := map[string]any{
+					"upid":       upid.Raw,
+					"node":       upid.Node,
+					"pid":        upid.PID,
+					"pstart":     upid.PStart,
+					"starttime":  upid.StartTime.Unix(),
+					"type":       upid.Type,
+					"id":         upid.ID,
+					"user":       upid.User,
+					"status":     status.Status,
+					"exitstatus": status.ExitStatus,
+					"duration":   int64(duration.Seconds()),
+				}
+
+				if !status.Running() && !ended.IsZero() {
+					data["endtime"] = ended.Unix()
+				}
+
+				return output.Print(data)
+			}
+
+			endedText := "—"
+			durationText := "unknown"
+
+			if status.Running() {
+				endedText = "(running)"
+			} else if !ended.IsZero() {
+				endedText = ended.Format("2006-01-02 15:04:05")
+			}
+
+			if !ended.IsZero() {
+				durationText = format.Duration(duration)
+			}
+
+			headers := []string{"FIELD", "VALUE"}
+			rows := [][]string{
+				{"UPID", upid.Raw},
+				{"Node", upid.Node},
+				{"PID", fmt.Sprintf("%d", upid.PID)},
+				{"Type", upid.Type},
+				{"ID", upid.ID},
+				{"User", upid.User},
+				{"Status", status.Status},
+				{"Exit Status", status.ExitStatus},
+				{"Started", upid.StartTime.Format("2006-01-02 15:04:05")},
+				{"Ended", endedText},
+				{"Duration", durationText},
+			}
+
+			output.Table(headers, rows)
+
+			return nil
+		},
+	}
+}
+
+// The status endpoint has no end time, so look the task up in the node's
+// task history using the start time encoded in the UPID.
+func taskEndTime(ctx context.Context, client *api.Client, upid *proxmox.UPID) time.Time {
+	tasks, err := client.Tasks.List(ctx, upid.Node, proxmox.TaskListOptions{
+		Source:     "all",
+		TypeFilter: upid.Type,
+		UserFilter: upid.User,
+		Since:      upid.StartTime,
+		Until:      upid.StartTime,
+	})
+	if err != nil {
+		return time.Time{}
+	}
+
+	for _, t := range tasks {
+		if t.UPID == upid.Raw && t.EndTime > 0 {
+			return time.Unix(int64(t.EndTime), 0)
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package task
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/cobra"
+)
+
+func stopCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "stop <upid>",
+		Short: "Stop a running task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			upid, err := proxmox.ParseUPID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := api.New(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if !force {
+				var confirm string
+
+				fmt.Printf("Stop %s task on node %s (%s)? [y/N]: ", upid.Type, upid.Node, upid.Raw)
+				_, _ = fmt.Scanln(&confirm)
+
+				if confirm != "y" && confirm != "Y" {
+					output.Aborted("Aborted.")
+					return api.ErrAborted
+				}
+			}
+
+			if err := client.Tasks.Stop(cmd.Context(), upid.Node, upid.Raw); err != nil {
+				return err
+			}
+
+			output.Done(output.Result{
+				Message: fmt.Sprintf("Stop requested for %s task %s", upid.Type, upid.Raw),
+				UPID:    upid.Raw,
+				Node:    upid.Node,
+			})
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+
+	return cmd
+}
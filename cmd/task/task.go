@@ -0,0 +1,42 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package task
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "task",
+		Short: "Inspect, follow, and stop Proxmox tasks",
+		Long: `Inspect, follow, and stop Proxmox tasks by UPID.
+
+The node, process, start time, type, guest ID, and user are decoded from the
+UPID itself, so --node is never required.
+
+Examples:
+  proxmoxctl task show UPID:pve1:000A1B2C:0153F1A2:65A4F0C1:qmstart:100:root@pam:
+  proxmoxctl task log UPID:pve1:000A1B2C:0153F1A2:65A4F0C1:qmstart:100:root@pam: --follow
+  proxmoxctl task stop UPID:pve1:000A1B2C:0153F1A2:65A4F0C1:vzdump::root@pam:`,
+	}
+
+	cmd.AddCommand(logCmd())
+	cmd.AddCommand(showCmd())
+	cmd.AddCommand(stopCmd())
+
+	return cmd
+}
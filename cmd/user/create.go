@@ -45,7 +45,7 @@ func createCmd() *cobra.Command {
 				return fmt.Errorf("userid must be in USER@REALM format (e.g. alice@pam)")
 			}
 
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -83,8 +83,7 @@ func createCmd() *cobra.Command {
 				payload["expire"] = expire
 			}
 
-			var resp any
-			if err := client.Post("/access/users", payload, &resp); err != nil {
+			if err := client.Access.CreateUser(cmd.Context(), payload); err != nil {
 				return err
 			}
 
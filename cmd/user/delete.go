@@ -31,7 +31,7 @@ func deleteCmd() *cobra.Command {
 		Short: "Delete a Proxmox user",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -44,11 +44,11 @@ func deleteCmd() *cobra.Command {
 
 				if confirm != "y" && confirm != "Y" {
 					output.Aborted("Aborted.")
-					return nil
+					return api.ErrAborted
 				}
 			}
 
-			if err := client.Delete(fmt.Sprintf("/access/users/%s", args[0])); err != nil {
+			if err := client.Access.DeleteUser(cmd.Context(), args[0]); err != nil {
 				return err
 			}
 
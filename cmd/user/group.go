@@ -17,10 +17,10 @@ package user
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -30,46 +30,49 @@ func groupCmd() *cobra.Command {
 		Short: "Show which groups a user belongs to",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data map[string]any `json:"data"`
-			}
-
-			if err := client.Get(fmt.Sprintf("/access/users/%s", args[0]), &resp); err != nil {
+			user, err := client.Access.User(cmd.Context(), args[0])
+			if err != nil {
 				return err
 			}
 
-			groups := toString(resp.Data["groups"])
+			groups := make([]proxmox.Group, 0, len(user.Groups))
 
-			if output.IsJSON() {
-				return output.JSON(map[string]any{
-					"userid": args[0],
-					"groups": strings.Split(groups, ","),
-				})
+			for _, g := range user.Groups {
+				groups = append(groups, proxmox.Group{GroupID: g})
 			}
 
-			if groups == "" {
-				fmt.Printf("User '%s' is not a member of any groups.\n", args[0])
-				return nil
+			columns := []output.Column[proxmox.Group]{
+				{Header: "GROUP ID", Field: "groupid", Value: func(g proxmox.Group) string { return g.GroupID }, Key: true},
+				{Header: "COMMENT", Field: "comment", Value: func(g proxmox.Group) string { return g.Comment }, Wide: true},
 			}
 
-			headers := []string{"GROUP"}
-			rows := [][]string{}
+			// The user record names its groups only; comments need /access/groups.
+			if output.Shows("comment", columns) {
+				all, err := client.Access.Groups(cmd.Context())
+				if err != nil {
+					return err
+				}
 
-			for _, g := range strings.Split(groups, ",") {
-				g = strings.TrimSpace(g)
-				if g != "" {
-					rows = append(rows, []string{g})
+				for i := range groups {
+					for _, g := range all {
+						if g.GroupID == groups[i].GroupID {
+							groups[i].Comment = g.Comment
+						}
+					}
 				}
 			}
 
-			output.Table(headers, rows)
+			if !output.IsBare() && len(groups) == 0 {
+				fmt.Printf("User '%s' is not a member of any groups.\n", args[0])
+				return nil
+			}
 
-			return nil
+			return output.List(groups, columns)
 		},
 	}
 }
@@ -17,7 +17,9 @@ package user
 
 import (
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/cobra"
 )
 
@@ -28,26 +30,21 @@ func listCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List all Proxmox users",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data []map[string]any `json:"data"`
-			}
-
-			if err := client.Get("/access/users", &resp); err != nil {
+			data, err := client.Access.Users(cmd.Context())
+			if err != nil {
 				return err
 			}
 
-			data := resp.Data
-
 			if enabledOnly {
 				filtered := data[:0]
 
 				for _, u := range data {
-					if enabled, ok := u["enable"].(bool); ok && enabled {
+					if u.Enable {
 						filtered = append(filtered, u)
 					}
 				}
@@ -55,28 +52,16 @@ func listCmd() *cobra.Command {
 				data = filtered
 			}
 
-			if output.IsJSON() {
-				return output.JSON(data)
-			}
-
-			headers := []string{"USERID", "FIRSTNAME", "LASTNAME", "EMAIL", "ENABLED", "EXPIRE", "GROUPS"}
-			rows := make([][]string, 0, len(data))
-
-			for _, u := range data {
-				rows = append(rows, []string{
-					toString(u["userid"]),
-					toString(u["firstname"]),
-					toString(u["lastname"]),
-					toString(u["email"]),
-					formatBool(u["enable"]),
-					formatExpire(u["expire"]),
-					toString(u["groups"]),
-				})
-			}
-
-			output.Table(headers, rows)
-
-			return nil
+			return output.List(data, []output.Column[proxmox.User]{
+				{Header: "USERID", Field: "userid", Value: func(u proxmox.User) string { return u.UserID }, Key: true},
+				{Header: "FIRSTNAME", Field: "firstname", Value: func(u proxmox.User) string { return u.FirstName }},
+				{Header: "LASTNAME", Field: "lastname", Value: func(u proxmox.User) string { return u.LastName }},
+				{Header: "EMAIL", Field: "email", Value: func(u proxmox.User) string { return u.Email }},
+				{Header: "ENABLED", Field: "enable", Value: func(u proxmox.User) string { return format.Bool(bool(u.Enable)) }},
+				{Header: "EXPIRE", Field: "expire", Value: func(u proxmox.User) string { return format.Expire(int64(u.Expire)) }},
+				{Header: "GROUPS", Field: "groups", Value: func(u proxmox.User) string { return format.List(u.Groups) }},
+				{Header: "COMMENT", Field: "comment", Value: func(u proxmox.User) string { return u.Comment }, Wide: true},
+			})
 		},
 	}
 
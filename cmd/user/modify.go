@@ -39,7 +39,7 @@ func modifyCmd() *cobra.Command {
 		Short: "Modify an existing Proxmox user",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -81,7 +81,7 @@ func modifyCmd() *cobra.Command {
 				return fmt.Errorf("no changes specified — use --firstname, --lastname, --email, --groups, --enabled, or --expire")
 			}
 
-			if err := client.Put(fmt.Sprintf("/access/users/%s", args[0]), payload, nil); err != nil {
+			if err := client.Access.UpdateUser(cmd.Context(), args[0], payload); err != nil {
 				return err
 			}
 
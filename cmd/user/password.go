@@ -32,7 +32,7 @@ func passwordCmd() *cobra.Command {
 		Short: "Change a user's password",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -54,7 +54,7 @@ func passwordCmd() *cobra.Command {
 				"password": password,
 			}
 
-			if err := client.Put("/access/password", payload, nil); err != nil {
+			if err := client.Access.ChangePassword(cmd.Context(), payload); err != nil {
 				return err
 			}
 
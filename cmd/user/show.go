@@ -16,9 +16,8 @@ limitations under the License.
 package user
 
 import (
-	"fmt"
-
 	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/internal/format"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -29,36 +28,31 @@ func showCmd() *cobra.Command {
 		Short: "Show details of a Proxmox user",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			var resp struct {
-				Data map[string]any `json:"data"`
-			}
-
-			if err := client.Get(fmt.Sprintf("/access/users/%s", args[0]), &resp); err != nil {
+			d, err := client.Access.User(cmd.Context(), args[0])
+			if err != nil {
 				return err
 			}
 
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
+			if output.IsStructured() {
+				return output.Print(d)
 			}
 
-			d := resp.Data
-
 			headers := []string{"FIELD", "VALUE"}
 			rows := [][]string{
-				{"User ID", toString(d["userid"])},
-				{"First Name", toString(d["firstname"])},
-				{"Last Name", toString(d["lastname"])},
-				{"Email", toString(d["email"])},
-				{"Comment", toString(d["comment"])},
-				{"Enabled", formatBool(d["enable"])},
-				{"Expire", formatExpire(d["expire"])},
-				{"Groups", toString(d["groups"])},
-				{"Keys", toString(d["keys"])},
+				{"User ID", d.UserID},
+				{"First Name", d.FirstName},
+				{"Last Name", d.LastName},
+				{"Email", d.Email},
+				{"Comment", d.Comment},
+				{"Enabled", format.Bool(bool(d.Enable))},
+				{"Expire", format.Expire(int64(d.Expire))},
+				{"Groups", format.List(d.Groups)},
+				{"Keys", d.Keys},
 			}
 
 			output.Table(headers, rows)
@@ -16,8 +16,6 @@ limitations under the License.
 package user
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
@@ -48,51 +46,3 @@ Examples:
 
 	return cmd
 }
-
-func formatBool(v interface{}) string {
-	if v == nil {
-		return "false"
-	}
-
-	switch val := v.(type) {
-	case bool:
-		if val {
-			return "true"
-		}
-
-		return "false"
-	case float64:
-		if val == 1 {
-			return "true"
-		}
-
-		return "false"
-	}
-
-	return fmt.Sprintf("%v", v)
-}
-
-func formatExpire(v interface{}) string {
-	if v == nil {
-		return "never"
-	}
-
-	switch val := v.(type) {
-	case float64:
-		if val == 0 {
-			return "never"
-		}
-
-		return fmt.Sprintf("%d", int64(val))
-	}
-
-	return "never"
-}
-
-func toString(v interface{}) string {
-	if v == nil {
-		return ""
-	}
-
-	return fmt.Sprintf("%v", v)
-}
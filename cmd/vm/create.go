@@ -32,13 +32,13 @@ func createCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create a new KVM VM",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			if node == "" {
-				node, err = client.DefaultNode()
+				node, err = client.DefaultNode(cmd.Context())
 				if err != nil {
 					return err
 				}
@@ -57,13 +57,21 @@ func createCmd() *cobra.Command {
 				"boot":   "order=scsi0;ide2",
 			}
 
-			var resp any
+			upid, err := client.Qemu.Create(cmd.Context(), node, payload)
+			if err != nil {
+				return err
+			}
 
-			if err := client.Post(fmt.Sprintf("/nodes/%s/qemu", node), payload, &resp); err != nil {
+			if err := client.Await(cmd.Context(), node, upid); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf("VM %d (%s) creation task queued on node %s", vmid, name, node))
+			output.Done(output.Result{
+				Message: fmt.Sprintf("VM %d (%s) creation task %s on node %s", vmid, name, api.TaskState(), node),
+				UPID:    upid,
+				Node:    node,
+				VMID:    vmid,
+			})
 
 			return nil
 		},
@@ -18,61 +18,21 @@ package vm
 import (
 	"fmt"
 
-	"github.com/dcjulian29/proxmoxctl/internal/api"
+	"github.com/dcjulian29/proxmoxctl/cmd/guest"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func listCmd() *cobra.Command {
-	var node string
-	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List all KVM VMs",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
-			if err != nil {
-				return err
-			}
-
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
-				}
-			}
-
-			var resp struct {
-				Data []map[string]any `json:"data"`
-			}
-
-			if err := client.Get(fmt.Sprintf("/nodes/%s/qemu", node), &resp); err != nil {
-				return err
-			}
-
-			if output.IsJSON() {
-				return output.JSON(resp.Data)
-			}
-
-			headers := []string{"VMID", "NAME", "STATUS", "MEM(MB)", "CPUS"}
-			rows := make([][]string, 0, len(resp.Data))
-
-			for _, v := range resp.Data {
-				rows = append(rows, []string{
-					fmt.Sprintf("%.0f", toFloat(v["vmid"])),
-					toString(v["name"]),
-					toString(v["status"]),
-					fmt.Sprintf("%.0f", toFloat(v["maxmem"])/1024/1024),
-					fmt.Sprintf("%.0f", toFloat(v["cpus"])),
-				})
-			}
-
-			output.Table(headers, rows)
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&node, "node", "", "Proxmox node name (auto-detected if not set)")
-
-	return cmd
+	return guest.ListCmd("qemu", "List KVM VMs across the cluster", `List VMs on every node of the cluster, from /cluster/resources.
+With --node, the node's own listing is used instead. The remaining flags
+filter the result; --tag may be repeated and all tags must match.
+
+Examples:
+  proxmoxctl vm list
+  proxmoxctl vm list --status stopped --tag prod
+  proxmoxctl vm list --name 'web-*' --no-template
+  proxmoxctl vm list --node pve2`,
+		output.Column[guest.ContextGuest]{Header: "CPUS", Field: "cpus", Value: func(g guest.ContextGuest) string { return fmt.Sprintf("%.0f", float64(g.CPUs)) }},
+	)
 }
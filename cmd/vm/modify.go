@@ -27,20 +27,18 @@ func modifyCmd() *cobra.Command {
 	var node, memory, cores, name string
 
 	cmd := &cobra.Command{
-		Use:   "modify <vmid>",
+		Use:   "modify <vmid|name>",
 		Short: "Modify an existing VM's configuration",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := api.New()
+			client, err := api.New(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			if node == "" {
-				node, err = client.DefaultNode()
-				if err != nil {
-					return err
-				}
+			guest, err := client.ResolveGuest(cmd.Context(), args[0], node, "qemu")
+			if err != nil {
+				return err
 			}
 
 			payload := map[string]any{}
@@ -61,11 +59,15 @@ func modifyCmd() *cobra.Command {
 				return fmt.Errorf("no changes specified — use --name, --memory, or --cores")
 			}
 
-			if err := client.Put(fmt.Sprintf("/nodes/%s/qemu/%s/config", node, args[0]), payload, nil); err != nil {
+			if err := client.Qemu.UpdateConfig(cmd.Context(), guest.Node, guest.VMID, payload); err != nil {
 				return err
 			}
 
-			output.Success(fmt.Sprintf("VM %s updated successfully", args[0]))
+			output.Done(output.Result{
+				Message: fmt.Sprintf("VM %d updated successfully", guest.VMID),
+				Node:    guest.Node,
+				VMID:    guest.VMID,
+			})
 
 			return nil
 		},
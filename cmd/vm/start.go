@@ -22,11 +22,11 @@ import (
 func startCmd() *cobra.Command {
 	var node string
 	cmd := &cobra.Command{
-		Use:   "start <vmid>",
+		Use:   "start <vmid|name>",
 		Short: "Start a VM",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return vmPowerAction(node, args[0], "start")
+			return vmPowerAction(cmd.Context(), node, args[0], "start")
 		},
 	}
 
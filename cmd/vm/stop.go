@@ -22,11 +22,11 @@ import (
 func stopCmd() *cobra.Command {
 	var node string
 	cmd := &cobra.Command{
-		Use:   "stop <vmid>",
+		Use:   "stop <vmid|name>",
 		Short: "Stop a VM",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return vmPowerAction(node, args[0], "stop")
+			return vmPowerAction(cmd.Context(), node, args[0], "stop")
 		},
 	}
 
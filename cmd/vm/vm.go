@@ -16,8 +16,8 @@ limitations under the License.
 package vm
 
 import (
+	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/dcjulian29/proxmoxctl/internal/api"
 	"github.com/dcjulian29/proxmoxctl/internal/output"
@@ -41,46 +41,32 @@ func NewCommand() *cobra.Command {
 	return cmd
 }
 
-func toFloat(v any) float64 {
-	switch val := v.(type) {
-	case float64:
-		return val
-	case string:
-		f, _ := strconv.ParseFloat(val, 64)
-		return f
-	}
-
-	return 0
-}
-
-func toString(v any) string {
-	if v == nil {
-		return ""
+func vmPowerAction(ctx context.Context, node, vmid, action string) error {
+	client, err := api.New(ctx)
+	if err != nil {
+		return err
 	}
 
-	return fmt.Sprintf("%v", v)
-}
-
-func vmPowerAction(node, vmid, action string) error {
-	client, err := api.New()
+	guest, err := client.ResolveGuest(ctx, vmid, node, "qemu")
 	if err != nil {
 		return err
 	}
 
-	if node == "" {
-		node, err = client.DefaultNode()
-		if err != nil {
-			return err
-		}
+	upid, err := client.Qemu.Action(ctx, guest.Node, guest.VMID, action)
+	if err != nil {
+		return err
 	}
 
-	var resp any
-
-	if err := client.Post(fmt.Sprintf("/nodes/%s/qemu/%s/status/%s", node, vmid, action), nil, &resp); err != nil {
+	if err := client.Await(ctx, guest.Node, upid); err != nil {
 		return err
 	}
 
-	output.Success(fmt.Sprintf("VM %s %s task queued", vmid, action))
+	output.Done(output.Result{
+		Message: fmt.Sprintf("VM %d %s task %s", guest.VMID, action, api.TaskState()),
+		UPID:    upid,
+		Node:    guest.Node,
+		VMID:    guest.VMID,
+	})
 
 	return nil
 }
@@ -16,164 +16,114 @@ limitations under the License.
 package api
 
 import (
-	"bytes"
-	"crypto/tls"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"time"
 
 	"github.com/dcjulian29/proxmoxctl/internal/color"
 	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
 	"github.com/spf13/viper"
 )
 
-const KeyInsecureSkipVerify = "tls_insecure"
+const (
+	KeyDefaultNode        = settings.KeyDefaultNode
+	KeyInsecureSkipVerify = settings.KeyTLSInsecure
+	KeyRequestTimeout     = "request_timeout"
+)
 
+// Client is the SDK client configured from proxmoxctl's settings, plus the
+// helpers only the CLI needs.
 type Client struct {
-	BaseURL    string
-	APIToken   string
-	Username   string
-	httpClient *http.Client
-}
-
-type APIResponse struct {
-	Data any `json:"data"`
+	*proxmox.Client
 }
 
-func New() (*Client, error) {
+// New returns a client for the configured server. It authenticates with the
+// API token (see APIToken) when one is configured and with the session from
+// `proxmoxctl login` otherwise.
+func New(ctx context.Context) (*Client, error) {
 	if err := settings.RequireConfig(); err != nil {
 		return nil, err
 	}
 
-	tls := allowInsecure()
-
-	transport := &http.Transport{
-		TLSClientConfig: tls,
+	token, err := APIToken(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return &Client{
-		BaseURL:  viper.GetString(settings.KeyServerURL),
-		APIToken: viper.GetString(settings.KeyAPIToken),
-		httpClient: &http.Client{
-			Timeout:   120 * time.Second, // 2 minutes
-			Transport: transport,
-		},
-	}, nil
-}
-
-func (c *Client) DefaultNode() (string, error) {
-	nodes, err := c.Nodes()
+	if token != "" {
+		return connect(proxmox.WithAPIToken(token))
+	}
 
+	anon, err := connect()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if len(nodes) == 0 {
-		return "", fmt.Errorf("no nodes found in cluster")
+	t, err := anon.loadTicket(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if name, ok := nodes[0]["node"].(string); ok {
-		fmt.Fprintln(os.Stderr, color.Info("Using node: "+name))
-		return name, nil
+	if t == nil {
+		return nil, fmt.Errorf("not authenticated — set an API token with `proxmoxctl config set` or run `proxmoxctl login`")
 	}
 
-	return "", fmt.Errorf("could not parse node name")
-}
-
-func (c *Client) Delete(path string) error {
-	return c.do(http.MethodDelete, path, nil, nil)
-}
-
-func (c *Client) Get(path string, dest any) error {
-	return c.do(http.MethodGet, path, nil, dest)
+	return connect(proxmox.WithAuthenticator(t))
 }
 
-func (c *Client) Nodes() ([]map[string]any, error) {
-	var resp struct {
-		Data []map[string]any `json:"data"`
-	}
-
-	if err := c.Get("/nodes", &resp); err != nil {
+// NewAnonymous returns a client with the configured connection settings but
+// no credentials, for logging in.
+func NewAnonymous() (*Client, error) {
+	if err := settings.RequireConfig(); err != nil {
 		return nil, err
 	}
 
-	return resp.Data, nil
-}
-
-func (c *Client) Post(path string, body any, dest any) error {
-	return c.do(http.MethodPost, path, body, dest)
+	return connect()
 }
 
-func (c *Client) Put(path string, body any, dest any) error {
-	return c.do(http.MethodPut, path, body, dest)
-}
-
-// Proxmox API tokens are formatted as "PVEAPIToken=USER@REALM!TOKENID=SECRET"
-func (c *Client) authHeader() string {
-	return fmt.Sprintf("PVEAPIToken=%s", c.APIToken)
-}
-
-func (c *Client) do(method, path string, body any, dest any) error {
-	url := fmt.Sprintf("%s/api2/json%s", c.BaseURL, path)
-
-	var reqBody io.Reader
-
-	if body != nil {
-		data, err := json.Marshal(body)
-
-		if err != nil {
-			return fmt.Errorf("marshal request: %w", err)
-		}
-
-		reqBody = bytes.NewReader(data)
-	}
-
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+// DefaultNode returns default_node when it is configured and the first node
+// of the cluster otherwise.
+func (c *Client) DefaultNode(ctx context.Context) (string, error) {
+	if node := viper.GetString(KeyDefaultNode); node != "" {
+		return node, nil
 	}
 
-	req.Header.Set("Authorization", c.authHeader())
-	req.Header.Set("Content-Type", "application/json")
+	nodes, err := c.Nodes.List(ctx)
 
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("\n%w", err)
+		return "", err
 	}
 
-	defer resp.Body.Close() //nolint:errcheck
-
-	respBytes, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if len(respBytes) > 0 {
-			return fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBytes))
-		} else {
-			return fmt.Errorf("API error %d", resp.StatusCode)
-		}
+	if len(nodes) == 0 {
+		return "", fmt.Errorf("no nodes found in cluster")
 	}
 
-	if dest != nil {
-		if err := json.Unmarshal(respBytes, dest); err != nil {
-			return fmt.Errorf("decode response: %w", err)
-		}
+	if nodes[0].Node == "" {
+		return "", fmt.Errorf("could not parse node name")
 	}
 
-	return nil
-}
+	fmt.Fprintln(os.Stderr, color.Info("Using node: "+nodes[0].Node))
 
-func allowInsecure() *tls.Config {
-	insecure := viper.GetBool(KeyInsecureSkipVerify)
+	return nodes[0].Node, nil
+}
 
-	tls := &tls.Config{InsecureSkipVerify: false} //nolint:gosec
+func connect(opts ...proxmox.Option) (*Client, error) {
+	tlsOpts, err := tlsOptions()
+	if err != nil {
+		return nil, err
+	}
 
-	if insecure {
-		// Accept self-signed certs common on Proxmox homelab installs.
-		tls.InsecureSkipVerify = true //nolint:gosec
+	c, err := proxmox.New(
+		viper.GetString(settings.KeyServerURL),
+		append(append(tlsOpts,
+			proxmox.WithTimeout(viper.GetDuration(KeyRequestTimeout)),
+			proxmox.WithRetryPolicy(retryPolicy()),
+		), opts...)...,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return tls
+	return &Client{Client: c}, nil
 }
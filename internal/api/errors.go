@@ -0,0 +1,219 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+)
+
+// Error is a failed API call; see proxmox.Error.
+type Error = proxmox.Error
+
+// Exit codes returned for outcomes that scripts commonly need to tell apart.
+// They are part of the CLI's interface; do not renumber them.
+const (
+	ExitOK          = 0
+	ExitError       = 1
+	ExitPermission  = 3
+	ExitNotFound    = 4
+	ExitConflict    = 5 // guest locked or resource already exists
+	ExitUnreachable = 6
+	ExitAborted     = 7
+	ExitTimeout     = 8
+)
+
+// ErrAborted ends a command the user declined at a confirmation prompt,
+// after output.Aborted has said so.
+const ErrAborted = ExitStatus(ExitAborted)
+
+// ErrTimeout marks a --wait that gave up before the task finished.
+var ErrTimeout = errors.New("timed out")
+
+// ExitStatus ends a command with a non-zero exit code when its output has
+// already described the failure, e.g. a failed `doctor` check.
+type ExitStatus int
+
+func (e ExitStatus) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+// Diagnosis is what the CLI knows about a failure beyond its message.
+type Diagnosis struct {
+	Hint     string
+	ExitCode int
+}
+
+// Diagnose maps well-known Proxmox failures to a hint and exit code.
+func Diagnose(err error) Diagnosis {
+	if errors.Is(err, ErrGuestNotFound) {
+		return Diagnosis{
+			Hint:     "Check the VMID or name with `proxmoxctl status resources --type vm`.",
+			ExitCode: ExitNotFound,
+		}
+	}
+
+	if errors.Is(err, ErrAmbiguousGuest) {
+		return Diagnosis{
+			Hint:     "Address the guest by its VMID instead.",
+			ExitCode: ExitError,
+		}
+	}
+
+	var pinErr *proxmox.FingerprintError
+	if errors.As(err, &pinErr) {
+		return Diagnosis{
+			Hint: "The server certificate no longer matches tls_fingerprint. If the certificate was " +
+				"renewed on purpose, run `proxmoxctl config set` to trust the new one.",
+			ExitCode: ExitUnreachable,
+		}
+	}
+
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return Diagnosis{
+			Hint: "The server certificate is not signed by a trusted CA. Run `proxmoxctl config set` to " +
+				"pin its fingerprint, or point tls_ca_file at your private CA bundle.",
+			ExitCode: ExitUnreachable,
+		}
+	}
+
+	if errors.Is(err, ErrTimeout) {
+		return Diagnosis{
+			Hint:     "The task is still running. Follow it with `proxmoxctl task log -f <upid>` or raise --timeout.",
+			ExitCode: ExitTimeout,
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return Diagnosis{
+			Hint:     "The server did not answer in time. Raise --request-timeout, or check the node with `proxmoxctl doctor`.",
+			ExitCode: ExitTimeout,
+		}
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return Diagnosis{ExitCode: ExitError}
+	}
+
+	msg := strings.ToLower(apiErr.Message)
+
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		return Diagnosis{
+			Hint:     "The server rejected the credentials. Check api_token with `proxmoxctl config show`, or run `proxmoxctl login` again.",
+			ExitCode: ExitPermission,
+		}
+	case apiErr.StatusCode == http.StatusForbidden || strings.Contains(msg, "permission check failed"):
+		return Diagnosis{
+			Hint: "The API token lacks the privilege named above. Grant a role on that path under " +
+				"Datacenter → Permissions, and remember that privilege-separated tokens need their own ACLs.",
+			ExitCode: ExitPermission,
+		}
+	case apiErr.StatusCode == 595 || apiErr.StatusCode == 596 || strings.Contains(msg, "no route to host"):
+		return Diagnosis{
+			Hint:     "The node that received the request cannot reach the target node. Check `proxmoxctl status cluster`.",
+			ExitCode: ExitUnreachable,
+		}
+	case strings.Contains(msg, "is locked") || strings.Contains(msg, "can't lock file"):
+		return Diagnosis{
+			Hint: "Another task (backup, migration, snapshot) holds the guest lock. Check " +
+				"`proxmoxctl status tasks --source active`, or clear a stale lock with `qm unlock`/`pct unlock`.",
+			ExitCode: ExitConflict,
+		}
+	case apiErr.StatusCode == http.StatusConflict || strings.Contains(msg, "already exists"):
+		return Diagnosis{
+			Hint:     "Pick another ID or name, or remove the existing resource first.",
+			ExitCode: ExitConflict,
+		}
+	case apiErr.StatusCode == http.StatusNotFound ||
+		strings.Contains(msg, "unable to find configuration file") ||
+		strings.Contains(msg, "does not exist"):
+		return Diagnosis{
+			Hint:     "The guest or resource does not exist on that node. Omit --node to locate guests automatically.",
+			ExitCode: ExitNotFound,
+		}
+	}
+
+	return Diagnosis{ExitCode: ExitError}
+}
+
+// ReportError prints err for the user (or as an output.Result with -o json
+// or yaml) and returns the exit code to use.
+func ReportError(err error) int {
+	d := Diagnose(err)
+
+	var apiErr *Error
+	isAPI := errors.As(err, &apiErr)
+
+	var status ExitStatus
+	if errors.As(err, &status) {
+		return int(status)
+	}
+
+	// Fleet mode has already written the merged results to stdout.
+	var partial *PartialError
+	if errors.As(err, &partial) {
+		fmt.Fprintln(os.Stderr, "\n"+color.Fatal(err))
+		return d.ExitCode
+	}
+
+	if output.IsStructured() && !output.IsTemplate() {
+		result := output.Result{
+			Status:   output.StatusError,
+			Message:  err.Error(),
+			ExitCode: d.ExitCode,
+			Hint:     d.Hint,
+		}
+
+		if isAPI {
+			result.Error = apiErr
+		}
+
+		output.Report(result)
+
+		return d.ExitCode
+	}
+
+	// Only list parameters separately when nothing wrapped the API error.
+	if isAPI && err == error(apiErr) {
+		fmt.Fprintln(os.Stderr, "\n"+color.Fatal(fmt.Sprintf("API error %d: %s", apiErr.StatusCode, apiErr.Message)))
+
+		for _, p := range apiErr.Params() {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", p, apiErr.Errors[p])
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, "\n"+color.Fatal(err))
+	}
+
+	if d.Hint != "" {
+		fmt.Fprintln(os.Stderr, color.Info("Hint: ")+d.Hint)
+	}
+
+	return d.ExitCode
+}
@@ -0,0 +1,170 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const KeyAllContexts = "all_contexts"
+
+// FleetAnnotation marks the read-only commands that accept --all-contexts.
+const FleetAnnotation = "proxmoxctl/fleet"
+
+// FleetResult is what one context returned in fleet mode.
+type FleetResult[T any] struct {
+	Context string
+	Items   []T
+	Err     error
+}
+
+// FleetError is the row structured output shows for a context that failed.
+type FleetError struct {
+	Context string `json:"context"`
+	Error   string `json:"error"`
+}
+
+// Failed reports whether r should be listed as a failed context in the
+// output itself. Structured output needs this because a reader of -o json
+// cannot tell a failed context from an empty one by stderr alone.
+func (r FleetResult[T]) Failed() bool {
+	return r.Err != nil && AllContexts() && output.IsStructured()
+}
+
+// AllContexts reports whether --all-contexts was given.
+func AllContexts() bool {
+	return viper.GetBool(KeyAllContexts)
+}
+
+// CheckFleet rejects --all-contexts on commands that do not support it, and
+// when there are no contexts to query.
+func CheckFleet(cmd *cobra.Command) error {
+	if !AllContexts() {
+		return nil
+	}
+
+	if cmd.Annotations[FleetAnnotation] == "" {
+		return fmt.Errorf("--all-contexts is not supported by `%s`; it only works with read-only listing commands", cmd.CommandPath())
+	}
+
+	if len(settings.Contexts()) == 0 {
+		return fmt.Errorf("--all-contexts: no contexts configured; add one with `proxmoxctl config add-context`")
+	}
+
+	return nil
+}
+
+// Fleet runs fn against every configured context in parallel, or against
+// the current configuration alone without --all-contexts (with Context set
+// to ""). A context that fails is reported in its result and does not stop
+// the others.
+func Fleet[T any](ctx context.Context, fn func(ctx context.Context, c *Client) ([]T, error)) []FleetResult[T] {
+	if !AllContexts() {
+		c, err := New(ctx)
+		if err != nil {
+			return []FleetResult[T]{{Err: err}}
+		}
+
+		items, err := fn(ctx, c)
+
+		return []FleetResult[T]{{Items: items, Err: err}}
+	}
+
+	names := settings.Contexts()
+	results := make([]FleetResult[T], len(names))
+	clients := make([]*Client, len(names))
+
+	// Clients are built one context at a time because the settings they
+	// read are global; the requests themselves then run concurrently.
+	for i, name := range names {
+		results[i].Context = name
+
+		if err := settings.UseContext(name); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		clients[i], results[i].Err = New(ctx)
+	}
+
+	_ = settings.UseContext(settings.SelectedContext())
+
+	var wg sync.WaitGroup
+
+	for i, c := range clients {
+		if c == nil {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			results[i].Items, results[i].Err = fn(ctx, c)
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// PartialError reports contexts that failed in fleet mode after the results
+// of the others were printed.
+type PartialError struct {
+	Failed int
+	Total  int
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d of %d contexts failed", e.Failed, e.Total)
+}
+
+// FleetErrors prints the contexts that failed to stderr and returns a
+// *PartialError when any did, so partial results still exit non-zero.
+// Outside fleet mode the single error is returned as is.
+func FleetErrors[T any](results []FleetResult[T]) error {
+	if !AllContexts() {
+		return results[0].Err
+	}
+
+	failed := 0
+
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+
+		failed++
+
+		fmt.Fprintln(os.Stderr, color.Fatal(fmt.Sprintf("⨯ context %s: %v", r.Context, r.Err)))
+	}
+
+	if failed == 0 {
+		return nil
+	}
+
+	return &PartialError{Failed: failed, Total: len(results)}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestCheckFleet(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	listing := &cobra.Command{Use: "list", Annotations: map[string]string{FleetAnnotation: "true"}}
+	deleting := &cobra.Command{Use: "delete"}
+
+	tests := []struct {
+		name     string
+		all      bool
+		contexts map[string]any
+		cmd      *cobra.Command
+		want     string
+	}{
+		{"flag not given", false, nil, deleting, ""},
+		{"unsupported command", true, map[string]any{"lab": map[string]any{}}, deleting, "not supported"},
+		{"no contexts", true, nil, listing, "no contexts configured"},
+		{"contexts", true, map[string]any{"lab": map[string]any{}}, listing, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set(KeyAllContexts, tt.all)
+
+			if tt.contexts != nil {
+				viper.Set(settings.KeyContexts, tt.contexts)
+			}
+
+			err := CheckFleet(tt.cmd)
+
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("CheckFleet = %v, want nil", err)
+			case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+				t.Errorf("CheckFleet = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
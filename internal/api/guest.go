@@ -0,0 +1,380 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+)
+
+// ErrGuestNotFound is returned when a VMID or name does not exist on any
+// cluster node.
+var ErrGuestNotFound = errors.New("guest not found")
+
+// ErrAmbiguousGuest is returned when a guest name matches more than one guest.
+var ErrAmbiguousGuest = errors.New("ambiguous guest name")
+
+// Guest identifies a VM or container and the node that currently owns it.
+type Guest struct {
+	VMID   int
+	Name   string
+	Node   string
+	Type   string // "qemu" or "lxc"
+	Status string
+}
+
+// ParseGuestRef splits a guest reference into a VMID or a name. Numbers are
+// VMIDs; anything else is a name, and a "name:" prefix forces a name for
+// guests whose name is all digits.
+func ParseGuestRef(ref string) (int, string, error) {
+	ref = strings.TrimSpace(ref)
+
+	if name, ok := strings.CutPrefix(ref, "name:"); ok {
+		if name == "" {
+			return 0, "", fmt.Errorf("invalid guest reference %q: missing name", ref)
+		}
+
+		return 0, name, nil
+	}
+
+	if ref == "" {
+		return 0, "", fmt.Errorf("invalid VMID %q", ref)
+	}
+
+	id, err := strconv.Atoi(ref)
+	if err != nil {
+		return 0, ref, nil
+	}
+
+	if id <= 0 {
+		return 0, "", fmt.Errorf("invalid VMID %q", ref)
+	}
+
+	return id, "", nil
+}
+
+// LocateGuest looks the VMID or guest name up in /cluster/resources so
+// node-scoped calls can be sent to the node that owns the guest.
+func (c *Client) LocateGuest(ctx context.Context, ref string) (*Guest, error) {
+	id, name, err := ParseGuestRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.locateGuest(ctx, id, name, "")
+}
+
+// LocateGuests looks up several VMIDs or guest names with a single
+// /cluster/resources request, returning the guests in the order given.
+func (c *Client) LocateGuests(ctx context.Context, refs []string) ([]*Guest, error) {
+	resources, err := c.Cluster.Resources(ctx, "vm")
+	if err != nil {
+		return nil, err
+	}
+
+	guests := make([]*Guest, 0, len(refs))
+
+	for _, ref := range refs {
+		id, name, err := ParseGuestRef(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		g, err := findGuest(resources, id, name, "")
+		if err != nil {
+			return nil, err
+		}
+
+		guests = append(guests, g)
+	}
+
+	return guests, nil
+}
+
+// ResolveGuest returns the guest addressed by ref, a VMID or guest name.
+// When node is set a VMID is trusted as-is and a name is only looked for on
+// that node; otherwise the owning node is located cluster-wide. A non-empty
+// gtype ("qemu" or "lxc") rejects guests of the other kind.
+func (c *Client) ResolveGuest(ctx context.Context, ref, node, gtype string) (*Guest, error) {
+	id, name, err := ParseGuestRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if node != "" && name == "" {
+		return &Guest{VMID: id, Node: node, Type: gtype}, nil
+	}
+
+	g, err := c.locateGuest(ctx, id, name, node)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtype != "" && g.Type != gtype {
+		return nil, fmt.Errorf("VMID %d is %s, not %s", g.VMID, guestKind(g.Type), guestKind(gtype))
+	}
+
+	switch {
+	case name != "":
+		fmt.Fprintln(os.Stderr, color.Info(fmt.Sprintf("Using VMID %d on node %s", g.VMID, g.Node)))
+	case node == "":
+		fmt.Fprintln(os.Stderr, color.Info("Using node: "+g.Node))
+	}
+
+	return g, nil
+}
+
+// locateGuest finds the guest with VMID id, or with the given name when
+// name is set. Names match case-insensitively across VMs and containers
+// alike, so a name shared by two guests is an error listing both. A
+// non-empty node limits the name search to that node.
+func (c *Client) locateGuest(ctx context.Context, id int, name, node string) (*Guest, error) {
+	resources, err := c.Cluster.Resources(ctx, "vm")
+	if err != nil {
+		return nil, err
+	}
+
+	return findGuest(resources, id, name, node)
+}
+
+func findGuest(resources []proxmox.ClusterResource, id int, name, node string) (*Guest, error) {
+	var matches []*Guest
+
+	for _, r := range resources {
+		if r.Type != "qemu" && r.Type != "lxc" {
+			continue
+		}
+
+		if name == "" && int(r.VMID) != id {
+			continue
+		}
+
+		if name != "" && (!strings.EqualFold(r.Name, name) || (node != "" && r.Node != node)) {
+			continue
+		}
+
+		matches = append(matches, &Guest{
+			VMID:   int(r.VMID),
+			Name:   r.Name,
+			Node:   r.Node,
+			Type:   r.Type,
+			Status: r.Status,
+		})
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1:
+		slices.SortFunc(matches, func(a, b *Guest) int { return a.VMID - b.VMID })
+
+		lines := make([]string, 0, len(matches))
+
+		for _, g := range matches {
+			lines = append(lines, fmt.Sprintf("  VMID %d: %s (%s on node %s)", g.VMID, g.Name, guestKind(g.Type), g.Node))
+		}
+
+		return nil, fmt.Errorf("%w: %q matches %d guests:\n%s",
+			ErrAmbiguousGuest, name, len(matches), strings.Join(lines, "\n"))
+	case name != "" && node != "":
+		return nil, fmt.Errorf("%w: no guest named %q on node %s", ErrGuestNotFound, name, node)
+	case name != "":
+		return nil, fmt.Errorf("%w: no guest named %q on any cluster node", ErrGuestNotFound, name)
+	}
+
+	return nil, fmt.Errorf("%w: VMID %d does not exist on any cluster node", ErrGuestNotFound, id)
+}
+
+// GuestService returns the SDK service for gtype ("qemu" or "lxc").
+func (c *Client) GuestService(gtype string) *proxmox.GuestService {
+	if gtype == "lxc" {
+		return c.LXC
+	}
+
+	return c.Qemu
+}
+
+// AddPools fills in the pool of each guest from /cluster/resources; the
+// per-node guest listings leave it out.
+func (c *Client) AddPools(ctx context.Context, guests []proxmox.GuestSummary) error {
+	resources, err := c.Cluster.Resources(ctx, "vm")
+	if err != nil {
+		return err
+	}
+
+	pools := map[proxmox.Int]string{}
+
+	for _, r := range resources {
+		pools[r.VMID] = r.Pool
+	}
+
+	for i := range guests {
+		guests[i].Pool = pools[guests[i].VMID]
+	}
+
+	return nil
+}
+
+// GuestFilter narrows a guest listing. Zero fields match everything.
+type GuestFilter struct {
+	Node     string
+	Status   string
+	Tags     []string // guests must carry every tag
+	Pool     string
+	Name     string // glob, e.g. "web-*"
+	Template *bool
+}
+
+// Match reports whether g passes every filter. Name patterns are assumed
+// valid; see ListGuests.
+func (f GuestFilter) Match(g proxmox.GuestSummary) bool {
+	if f.Node != "" && g.Node != f.Node {
+		return false
+	}
+
+	if f.Status != "" && !strings.EqualFold(g.Status, f.Status) {
+		return false
+	}
+
+	for _, tag := range f.Tags {
+		if !slices.ContainsFunc(g.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+
+	if f.Pool != "" && g.Pool != f.Pool {
+		return false
+	}
+
+	if f.Name != "" {
+		if ok, _ := path.Match(strings.ToLower(f.Name), strings.ToLower(g.Name)); !ok {
+			return false
+		}
+	}
+
+	if f.Template != nil && bool(g.Template) != *f.Template {
+		return false
+	}
+
+	return true
+}
+
+// ListGuests returns the guests of gtype ("qemu" or "lxc") that match f,
+// ordered by VMID. The cluster-wide view comes from /cluster/resources;
+// with f.Node set the node's own listing is used instead, and withPools
+// fills in the pool it leaves out.
+func (c *Client) ListGuests(ctx context.Context, gtype string, f GuestFilter, withPools bool) ([]proxmox.GuestSummary, error) {
+	if _, err := path.Match(f.Name, ""); err != nil {
+		return nil, fmt.Errorf("invalid --name pattern %q: %w", f.Name, err)
+	}
+
+	switch strings.ToLower(f.Status) {
+	case "", "running", "stopped":
+	default:
+		return nil, fmt.Errorf("invalid --status %q: must be running or stopped", f.Status)
+	}
+
+	var guests []proxmox.GuestSummary
+
+	if f.Node != "" {
+		list, err := c.GuestService(gtype).List(ctx, f.Node)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range list {
+			list[i].Node = f.Node
+			list[i].Type = gtype
+		}
+
+		if withPools || f.Pool != "" {
+			if err := c.AddPools(ctx, list); err != nil {
+				return nil, err
+			}
+		}
+
+		guests = list
+	} else {
+		resources, err := c.Cluster.Resources(ctx, "vm")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range resources {
+			if r.Type == gtype {
+				guests = append(guests, guestSummary(r))
+			}
+		}
+	}
+
+	matched := make([]proxmox.GuestSummary, 0, len(guests))
+
+	for _, g := range guests {
+		if f.Match(g) {
+			matched = append(matched, g)
+		}
+	}
+
+	slices.SortFunc(matched, func(a, b proxmox.GuestSummary) int {
+		return int(a.VMID) - int(b.VMID)
+	})
+
+	return matched, nil
+}
+
+// guestSummary converts a /cluster/resources entry to the shape the node
+// listings return.
+func guestSummary(r proxmox.ClusterResource) proxmox.GuestSummary {
+	return proxmox.GuestSummary{
+		VMID:      r.VMID,
+		Name:      r.Name,
+		Node:      r.Node,
+		Type:      r.Type,
+		Pool:      r.Pool,
+		Status:    r.Status,
+		Lock:      r.Lock,
+		Tags:      r.Tags,
+		Template:  r.Template,
+		CPU:       r.CPU,
+		CPUs:      r.MaxCPU,
+		Mem:       r.Mem,
+		MaxMem:    r.MaxMem,
+		Disk:      r.Disk,
+		MaxDisk:   r.MaxDisk,
+		DiskRead:  r.DiskRead,
+		DiskWrite: r.DiskWrite,
+		NetIn:     r.NetIn,
+		NetOut:    r.NetOut,
+		Uptime:    r.Uptime,
+	}
+}
+
+func guestKind(gtype string) string {
+	if gtype == "lxc" {
+		return "an LXC container"
+	}
+
+	return "a KVM VM"
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/viper"
+)
+
+const (
+	KeyRetryAttempts = "retry_attempts"
+	KeyRetryDelay    = "retry_delay"
+	KeyRetryMaxDelay = "retry_max_delay"
+	KeyVerbose       = "verbose"
+)
+
+// retryPolicy starts from the SDK defaults and applies any overrides from the
+// config file or environment. In verbose mode every retry is logged to stderr.
+func retryPolicy() proxmox.RetryPolicy {
+	p := proxmox.DefaultRetryPolicy
+
+	if viper.IsSet(KeyRetryAttempts) {
+		p.MaxAttempts = viper.GetInt(KeyRetryAttempts)
+	}
+
+	if viper.IsSet(KeyRetryDelay) {
+		p.BaseDelay = viper.GetDuration(KeyRetryDelay)
+	}
+
+	if viper.IsSet(KeyRetryMaxDelay) {
+		p.MaxDelay = viper.GetDuration(KeyRetryMaxDelay)
+	}
+
+	if viper.GetBool(KeyVerbose) {
+		p.OnRetry = func(method, path string, attempt int, wait time.Duration, err error) {
+			fmt.Fprintln(os.Stderr, color.Warn(fmt.Sprintf(
+				"%s %s failed (%v); retrying in %s (attempt %d of %d)",
+				method, path, err, wait.Round(time.Millisecond), attempt+1, p.MaxAttempts,
+			)))
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,90 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/dcjulian29/proxmoxctl/internal/output"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/viper"
+)
+
+const (
+	KeyWait        = "wait"
+	KeyWaitTimeout = "wait_timeout"
+)
+
+// Await blocks until the task finishes when --wait is set, streaming its log
+// to stderr in table mode. It returns an error if the task failed.
+func (c *Client) Await(ctx context.Context, node, upid string) error {
+	if !viper.GetBool(KeyWait) || upid == "" {
+		return nil
+	}
+
+	var log io.Writer
+
+	if !output.IsStructured() && !output.IsQuiet() {
+		log = os.Stderr
+		fmt.Fprintln(os.Stderr, color.Info("Waiting for task "+upid))
+	}
+
+	status, err := c.WaitForTask(ctx, node, upid, log)
+	if err != nil {
+		return err
+	}
+
+	if !status.Succeeded() {
+		return fmt.Errorf("task %s failed: %s", upid, status.ExitStatus)
+	}
+
+	return nil
+}
+
+// WaitForTask waits for the task to stop, giving up after --timeout
+// (0 = no limit). New log lines are written to log when it is not nil.
+func (c *Client) WaitForTask(ctx context.Context, node, upid string, log io.Writer) (*proxmox.TaskStatus, error) {
+	timeout := viper.GetDuration(KeyWaitTimeout)
+
+	waitCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	status, err := c.Tasks.Wait(waitCtx, node, upid, log)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, fmt.Errorf("%w after %s waiting for task %s (it is still running)", ErrTimeout, timeout, upid)
+	}
+
+	return status, err
+}
+
+// TaskState describes a queued task in success messages.
+func TaskState() string {
+	if viper.GetBool(KeyWait) {
+		return "completed"
+	}
+
+	return "queued"
+}
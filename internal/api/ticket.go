@@ -0,0 +1,126 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/viper"
+)
+
+// ticketRenewAfter is the session age at which the next command trades the
+// ticket for a fresh one, well inside proxmox.TicketLifetime.
+const ticketRenewAfter = time.Hour
+
+// cachedTicket is the session written by `proxmoxctl login`. It is tied to
+// the server it was issued by.
+type cachedTicket struct {
+	Server string `json:"server"`
+	proxmox.Ticket
+}
+
+// SaveTicket caches a session for the configured server. The file holds a
+// credential, so it is readable by the owner only.
+func SaveTicket(t *proxmox.Ticket) error {
+	path, err := ticketPath()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(cachedTicket{Server: viper.GetString(settings.KeyServerURL), Ticket: *t}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("could not save session: %w", err)
+	}
+
+	// WriteFile keeps the mode of an existing file.
+	return os.Chmod(path, 0600)
+}
+
+// loadTicket returns the cached session for the configured server, renewing
+// it when it is older than ticketRenewAfter. It returns nil when there is no
+// usable session.
+func (c *Client) loadTicket(ctx context.Context) (*proxmox.Ticket, error) {
+	path, err := ticketPath()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read session: %w", err)
+	}
+
+	var cached cachedTicket
+	if err := json.Unmarshal(b, &cached); err != nil {
+		return nil, fmt.Errorf("could not read session: %w", err)
+	}
+
+	if cached.Server != viper.GetString(settings.KeyServerURL) || cached.NeedTFA {
+		return nil, nil
+	}
+
+	t := &cached.Ticket
+
+	if time.Now().After(t.Expires()) {
+		return nil, fmt.Errorf("session expired at %s — run `proxmoxctl login`", t.Expires().Format("2006-01-02 15:04"))
+	}
+
+	if time.Since(t.Issued) < ticketRenewAfter {
+		return t, nil
+	}
+
+	renewed, err := c.Access.RenewTicket(ctx, t)
+	if err != nil {
+		return nil, fmt.Errorf("could not renew session: %w", err)
+	}
+
+	if err := SaveTicket(renewed); err != nil {
+		return nil, err
+	}
+
+	return renewed, nil
+}
+
+// ticketPath keeps one session per context so switching contexts does not
+// log the others out.
+func ticketPath() (string, error) {
+	dir, err := settings.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	if name := settings.CurrentContext(); name != "" {
+		return filepath.Join(dir, "ticket-"+name+".json"), nil
+	}
+
+	return filepath.Join(dir, "ticket.json"), nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/viper"
+)
+
+func TestTicketPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	config := filepath.Join(home, "config.yml")
+	data := "server_url: https://pve.example.com:8006\ncontexts:\n  lab:\n    server_url: https://lab.example.com:8006\n"
+
+	if err := os.WriteFile(config, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.SetConfigFile(config)
+	t.Cleanup(viper.Reset)
+
+	dir := filepath.Join(home, ".config", "proxmoxctl")
+
+	tests := []struct {
+		context string
+		want    string
+	}{
+		{"", "ticket.json"},
+		{"lab", "ticket-lab.json"},
+	}
+
+	for _, tt := range tests {
+		if err := settings.UseContext(tt.context); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ticketPath()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := filepath.Join(dir, tt.want); got != want {
+			t.Errorf("ticketPath() with context %q = %s, want %s", tt.context, got, want)
+		}
+	}
+
+	if err := settings.UseContext(""); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/dcjulian29/proxmoxctl/pkg/proxmox"
+	"github.com/spf13/viper"
+)
+
+const (
+	KeyTLSCAFile      = settings.KeyTLSCAFile
+	KeyTLSFingerprint = settings.KeyTLSFingerprint
+)
+
+// ServerCertificate describes the certificate a server presents and whether
+// it is trusted without a pinned fingerprint.
+type ServerCertificate struct {
+	Fingerprint string
+	Subject     string
+	Issuer      string
+	NotAfter    time.Time
+	Trusted     bool
+}
+
+// Matches reports whether fingerprint, in any case and with or without
+// colons, is the fingerprint of the certificate.
+func (c *ServerCertificate) Matches(fingerprint string) bool {
+	strip := strings.NewReplacer(":", "", " ", "")
+
+	return fingerprint != "" && strings.EqualFold(strip.Replace(fingerprint), strip.Replace(c.Fingerprint))
+}
+
+// ProbeCertificate fetches the certificate presented at serverURL and checks
+// it against the system roots plus the PEM bundle in caFile, if any.
+func ProbeCertificate(ctx context.Context, serverURL, caFile string) (*ServerCertificate, error) {
+	chain, err := proxmox.ServerCertificates(ctx, serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("%s presented no certificate", serverURL)
+	}
+
+	roots, err := rootCAs(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	u, _ := url.Parse(serverURL)
+	intermediates := x509.NewCertPool()
+
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+
+	_, verifyErr := chain[0].Verify(x509.VerifyOptions{
+		DNSName:       u.Hostname(),
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+
+	return &ServerCertificate{
+		Fingerprint: proxmox.Fingerprint(chain[0]),
+		Subject:     chain[0].Subject.String(),
+		Issuer:      chain[0].Issuer.String(),
+		NotAfter:    chain[0].NotAfter,
+		Trusted:     verifyErr == nil,
+	}, nil
+}
+
+// rootCAs returns the system roots with the certificates in file added, or
+// nil (meaning the system roots) when file is "".
+func rootCAs(file string) (*x509.CertPool, error) {
+	if file == "" {
+		return nil, nil
+	}
+
+	path, err := settings.ExpandHome(file)
+	if err != nil {
+		return nil, err
+	}
+
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", KeyTLSCAFile, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%s: no PEM certificates found in %s", KeyTLSCAFile, file)
+	}
+
+	return pool, nil
+}
+
+func tlsOptions() ([]proxmox.Option, error) {
+	opts := []proxmox.Option{
+		// Accept self-signed certs common on Proxmox homelab installs.
+		proxmox.WithInsecureSkipVerify(viper.GetBool(KeyInsecureSkipVerify)),
+	}
+
+	roots, err := rootCAs(viper.GetString(KeyTLSCAFile))
+	if err != nil {
+		return nil, err
+	}
+
+	if roots != nil {
+		opts = append(opts, proxmox.WithRootCAs(roots))
+	}
+
+	if fp := viper.GetString(KeyTLSFingerprint); fp != "" {
+		opts = append(opts, proxmox.WithFingerprint(fp))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dcjulian29/proxmoxctl/internal/settings"
+	"github.com/spf13/viper"
+)
+
+// tokenCommandTimeout bounds api_token_command, leaving time to unlock a
+// password store.
+const tokenCommandTimeout = time.Minute
+
+// APIToken returns the API token from api_token, the file named by
+// api_token_file, or the output of api_token_command, checked in that order.
+// It returns "" when none is configured. ctx bounds api_token_command, so
+// cancelling it stops a helper that hangs.
+func APIToken(ctx context.Context) (string, error) {
+	if token := viper.GetString(settings.KeyAPIToken); token != "" {
+		return token, nil
+	}
+
+	if file := viper.GetString(settings.KeyAPITokenFile); file != "" {
+		return tokenFromFile(file)
+	}
+
+	if command := viper.GetString(settings.KeyAPITokenCommand); command != "" {
+		return tokenFromCommand(ctx, command)
+	}
+
+	return "", nil
+}
+
+// TokenSource describes where APIToken reads the token from without
+// revealing it, or returns "" when no token is configured.
+func TokenSource() string {
+	switch {
+	case viper.GetString(settings.KeyAPIToken) != "":
+		if os.Getenv("PROXMOX_API_TOKEN") != "" {
+			return "PROXMOX_API_TOKEN"
+		}
+
+		return settings.KeyAPIToken
+	case viper.GetString(settings.KeyAPITokenFile) != "":
+		return fmt.Sprintf("%s (%s)", settings.KeyAPITokenFile, viper.GetString(settings.KeyAPITokenFile))
+	case viper.GetString(settings.KeyAPITokenCommand) != "":
+		return fmt.Sprintf("%s (%s)", settings.KeyAPITokenCommand, viper.GetString(settings.KeyAPITokenCommand))
+	}
+
+	return ""
+}
+
+// tokenFromFile reads a token file, refusing one other users can read, the
+// same way ssh treats private keys.
+func tokenFromFile(path string) (string, error) {
+	path, err := settings.ExpandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", settings.KeyAPITokenFile, err)
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return "", fmt.Errorf("%s: %s is accessible by other users (mode %04o) — run `chmod 600 %s`",
+			settings.KeyAPITokenFile, path, info.Mode().Perm(), path)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", settings.KeyAPITokenFile, err)
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("%s: %s is empty", settings.KeyAPITokenFile, path)
+	}
+
+	return token, nil
+}
+
+// tokenFromCommand runs command through the shell and returns the first
+// line it prints. Stdin and stderr stay attached so the helper can prompt,
+// e.g. for a GPG passphrase.
+func tokenFromCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout bytes.Buffer
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s failed: %w", settings.KeyAPITokenCommand, err)
+	}
+
+	// Tools like `pass show` put the secret on the first line.
+	token, _, _ := strings.Cut(stdout.String(), "\n")
+	token = strings.TrimSpace(token)
+
+	if token == "" {
+		return "", fmt.Errorf("%s printed nothing", settings.KeyAPITokenCommand)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestTokenFromCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	token, err := tokenFromCommand(context.Background(), "printf 'root@pam!ci=secret\\nextra\\n'")
+	if err != nil || token != "root@pam!ci=secret" {
+		t.Errorf("tokenFromCommand = %q, %v; want the first line", token, err)
+	}
+
+	if _, err := tokenFromCommand(context.Background(), "true"); err == nil {
+		t.Error("tokenFromCommand with no output succeeded, want an error")
+	}
+}
+
+func TestTokenFromCommandCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	if _, err := tokenFromCommand(ctx, "exec sleep 30"); err == nil {
+		t.Error("tokenFromCommand succeeded, want an error once the context is done")
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("tokenFromCommand returned after %v, want it stopped with its context", elapsed)
+	}
+}
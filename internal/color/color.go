@@ -15,27 +15,133 @@ limitations under the License.
 */
 package color
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
 
+	"golang.org/x/term"
+)
+
+const KeyNoColor = "no_color"
+
+const (
+	black   = "\033[1;30m%s\033[0m"
+	red     = "\033[1;31m%s\033[0m"
+	green   = "\033[1;32m%s\033[0m"
+	yellow  = "\033[1;33m%s\033[0m"
+	purple  = "\033[1;34m%s\033[0m"
+	magenta = "\033[1;35m%s\033[0m"
+	teal    = "\033[1;36m%s\033[0m"
+	white   = "\033[1;37m%s\033[0m"
+)
+
+// Info, Warn, and Fatal color diagnostics written to stderr; the others
+// color regular output on stdout.
 var (
-	Info    = Teal
-	Warn    = Yellow
-	Fatal   = Red
-	Black   = Color("\033[1;30m%s\033[0m")
-	Red     = Color("\033[1;31m%s\033[0m")
-	Green   = Color("\033[1;32m%s\033[0m")
-	Yellow  = Color("\033[1;33m%s\033[0m")
-	Purple  = Color("\033[1;34m%s\033[0m")
-	Magenta = Color("\033[1;35m%s\033[0m")
-	Teal    = Color("\033[1;36m%s\033[0m")
-	White   = Color("\033[1;37m%s\033[0m")
+	Info    = ErrColor(teal)
+	Warn    = ErrColor(yellow)
+	Fatal   = ErrColor(red)
+	Black   = Color(black)
+	Red     = Color(red)
+	Green   = Color(green)
+	Yellow  = Color(yellow)
+	Purple  = Color(purple)
+	Magenta = Color(magenta)
+	Teal    = Color(teal)
+	White   = Color(white)
+	Grey    = Black
 )
 
+var (
+	detectOut = sync.OnceValue(func() bool { return detectEnabled(os.Stdout) })
+	detectErr = sync.OnceValue(func() bool { return detectEnabled(os.Stderr) })
+	disabled  bool
+)
+
+// Color returns a function that colors text written to stdout.
 func Color(colorString string) func(...interface{}) string {
+	return paint(colorString, Enabled)
+}
+
+// ErrColor returns a function that colors text written to stderr.
+func ErrColor(colorString string) func(...interface{}) string {
+	return paint(colorString, ErrEnabled)
+}
+
+func paint(colorString string, enabled func() bool) func(...interface{}) string {
 	sprint := func(args ...interface{}) string {
+		if !enabled() {
+			return fmt.Sprint(args...)
+		}
+
 		return fmt.Sprintf(colorString,
 			fmt.Sprint(args...))
 	}
 
 	return sprint
 }
+
+// Disable turns colors off, as --no-color does.
+func Disable() {
+	disabled = true
+}
+
+// Enabled reports whether stdout is colored: never with --no-color or
+// NO_COLOR, always with CLICOLOR_FORCE, and otherwise only when stdout is a
+// terminal.
+func Enabled() bool {
+	return !disabled && detectOut()
+}
+
+// ErrEnabled is Enabled for stderr, so warnings keep their color when only
+// stdout is piped or redirected.
+func ErrEnabled() bool {
+	return !disabled && detectErr()
+}
+
+func detectEnabled(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// Status colors a guest, node, storage, or task state: running and healthy
+// states green, stopped ones grey, and failures red.
+func Status(s string) string {
+	switch strings.ToLower(s) {
+	case "running", "online", "active", "available", "ok":
+		return Green(s)
+	case "stopped", "offline", "inactive", "disabled", "unknown":
+		return Grey(s)
+	case "paused", "suspended", "prelaunch":
+		return Yellow(s)
+	case "error", "failed", "internal-error", "io-error":
+		return Red(s)
+	}
+
+	if lower := strings.ToLower(s); strings.Contains(lower, "error") || strings.Contains(lower, "fail") {
+		return Red(s)
+	}
+
+	return s
+}
+
+var ansi = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// Strip removes color escapes from s.
+func Strip(s string) string {
+	return ansi.ReplaceAllString(s, "")
+}
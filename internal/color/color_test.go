@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package color
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestDetectEnabled(t *testing.T) {
+	// A regular file stands in for a redirected stream.
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	tests := []struct {
+		name    string
+		noColor string
+		force   string
+		term    string
+		want    bool
+	}{
+		{"not a terminal", "", "", "xterm", false},
+		{"CLICOLOR_FORCE", "", "1", "xterm", true},
+		{"CLICOLOR_FORCE=0", "", "0", "xterm", false},
+		{"NO_COLOR beats CLICOLOR_FORCE", "1", "1", "xterm", false},
+		{"CLICOLOR_FORCE beats TERM=dumb", "", "1", "dumb", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("CLICOLOR_FORCE", tt.force)
+			t.Setenv("TERM", tt.term)
+
+			if got := detectEnabled(f); got != tt.want {
+				t.Errorf("detectEnabled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisableBeatsForce(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "1")
+
+	out, errOut := detectOut, detectErr
+	detectOut = sync.OnceValue(func() bool { return detectEnabled(os.Stdout) })
+	detectErr = sync.OnceValue(func() bool { return detectEnabled(os.Stderr) })
+
+	t.Cleanup(func() {
+		detectOut, detectErr, disabled = out, errOut, false
+	})
+
+	if got := Red("x"); got != "\033[1;31mx\033[0m" {
+		t.Errorf("Red with CLICOLOR_FORCE = %q, want it colored", got)
+	}
+
+	if got := Warn("x"); got != "\033[1;33mx\033[0m" {
+		t.Errorf("Warn with CLICOLOR_FORCE = %q, want it colored", got)
+	}
+
+	Disable()
+
+	if Enabled() || ErrEnabled() {
+		t.Error("Enabled or ErrEnabled after Disable, want neither")
+	}
+
+	if got := Red("x") + Warn("x"); got != "xx" {
+		t.Errorf("colors after Disable = %q, want plain text", got)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package format
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+func Bar(used, total float64, width int) string {
+	if total == 0 {
+		return "n/a"
+	}
+
+	pct := used / total
+	filled := max(min(int(math.Round(pct*float64(width))), width), 0)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+
+	return fmt.Sprintf("[%s] %.1f%%", bar, pct*100)
+}
+
+func Bool(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func Bytes(b float64) string {
+	const unit = 1024.0
+
+	if b < unit {
+		return fmt.Sprintf("%.0f B", b)
+	}
+
+	div, exp := unit, 0
+
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", b/div, "KMGTPE"[exp])
+}
+
+// Duration formats an elapsed time to the second.
+func Duration(d time.Duration) string {
+	d = d.Round(time.Second)
+
+	if d < time.Minute {
+		return d.String()
+	}
+
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+
+	if h > 0 {
+		return fmt.Sprintf("%dh %dm %ds", h, m, s)
+	}
+
+	return fmt.Sprintf("%dm %ds", m, s)
+}
+
+// Epoch formats a Unix timestamp, leaving unset (zero) values blank.
+func Epoch(sec int64) string {
+	if sec <= 0 {
+		return ""
+	}
+
+	return time.Unix(sec, 0).Format(timeLayout)
+}
+
+func Expire(sec int64) string {
+	if sec <= 0 {
+		return "never"
+	}
+
+	return Epoch(sec)
+}
+
+func List(items []string) string {
+	return strings.Join(items, ", ")
+}
+
+func MB(b float64) string {
+	return fmt.Sprintf("%.0f", b/1024/1024)
+}
+
+func Percent(fraction float64) string {
+	return fmt.Sprintf("%.1f%%", fraction*100)
+}
+
+func Uptime(seconds int64) string {
+	if seconds <= 0 {
+		return "—"
+	}
+
+	d := time.Duration(seconds) * time.Second
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	}
+
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// VMID formats a guest ID, showing a dash for content not owned by a guest.
+func VMID(id int64) string {
+	if id == 0 {
+		return "—"
+	}
+
+	return fmt.Sprintf("%d", id)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package output
+
+import (
+	"strconv"
+
+	"github.com/dcjulian29/proxmoxctl/internal/format"
+)
+
+// The cell helpers render a number for the selected format: readable text
+// in tables, the raw value in CSV and TSV so spreadsheets can use it.
+
+// Bytes renders a byte count, e.g. "1.5 GB".
+func Bytes(b float64) string {
+	if IsDelimited() {
+		return rawNumber(b)
+	}
+
+	return format.Bytes(b)
+}
+
+// Percent renders a 0..1 fraction, e.g. "12.5%".
+func Percent(fraction float64) string {
+	if IsDelimited() {
+		return rawNumber(fraction)
+	}
+
+	return format.Percent(fraction)
+}
+
+// Usage renders used/total as a bar, or as a plain fraction in CSV and TSV.
+func Usage(used, total float64, width int) string {
+	if IsDelimited() {
+		if total == 0 {
+			return ""
+		}
+
+		return rawNumber(used / total)
+	}
+
+	return format.Bar(used, total, width)
+}
+
+// Uptime renders seconds as "1d 2h 3m", or the seconds in CSV and TSV.
+func Uptime(seconds int64) string {
+	if IsDelimited() {
+		return strconv.FormatInt(seconds, 10)
+	}
+
+	return format.Uptime(seconds)
+}
+
+func rawNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
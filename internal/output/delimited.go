@@ -0,0 +1,74 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+)
+
+func writeCSV(headers []string, rows [][]string) {
+	w := csv.NewWriter(os.Stdout)
+
+	if headers != nil {
+		_ = w.Write(headers)
+	}
+
+	for _, row := range rows {
+		_ = w.Write(plain(row))
+	}
+
+	w.Flush()
+}
+
+// tsvEscaper keeps every record on one line, as in the linear TSV
+// convention: tabs, newlines, and backslashes are written as escapes.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func writeTSV(headers []string, rows [][]string) {
+	if headers != nil {
+		writeTSVRow(headers)
+	}
+
+	for _, row := range rows {
+		writeTSVRow(row)
+	}
+}
+
+func writeTSVRow(row []string) {
+	fields := make([]string, len(row))
+
+	for i, f := range row {
+		fields[i] = tsvEscaper.Replace(color.Strip(f))
+	}
+
+	fmt.Println(strings.Join(fields, "\t"))
+}
+
+// plain strips color from the cells of a row.
+func plain(row []string) []string {
+	out := make([]string, len(row))
+
+	for i, cell := range row {
+		out[i] = color.Strip(cell)
+	}
+
+	return out
+}
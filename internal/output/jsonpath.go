@@ -0,0 +1,706 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a parsed kubectl-style JSONPath template such as
+// `{range .[*]}{.name}{"\t"}{.node}{"\n"}{end}`.
+type jsonPath struct {
+	nodes []jpNode
+}
+
+type jpNode struct {
+	text    string
+	path    []jpStep
+	expr    bool
+	body    []jpNode // range body
+	isRange bool
+}
+
+type jpStepKind int
+
+const (
+	jpField jpStepKind = iota
+	jpWildcard
+	jpIndex
+	jpSlice
+	jpFilter
+)
+
+type jpStep struct {
+	kind      jpStepKind
+	names     []string
+	recursive bool
+	index     int
+	start     *int
+	end       *int
+	filter    *jpCond
+}
+
+type jpCond struct {
+	path  []jpStep
+	op    string
+	value any
+}
+
+func parseJSONPath(tmpl string) (*jsonPath, error) {
+	if !strings.Contains(tmpl, "{") {
+		tmpl = "{" + tmpl + "}"
+	}
+
+	var (
+		stack [][]jpNode
+		cur   []jpNode
+	)
+
+	for len(tmpl) > 0 {
+		open := strings.IndexByte(tmpl, '{')
+		if open < 0 {
+			cur = append(cur, jpNode{text: tmpl})
+			break
+		}
+
+		if open > 0 {
+			cur = append(cur, jpNode{text: tmpl[:open]})
+		}
+
+		end := closingBrace(tmpl, open)
+		if end < 0 {
+			return nil, fmt.Errorf("jsonpath: unclosed '{' in %q", tmpl)
+		}
+
+		expr := strings.TrimSpace(tmpl[open+1 : end])
+		tmpl = tmpl[end+1:]
+
+		switch {
+		case expr == "end":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("jsonpath: {end} without {range}")
+			}
+
+			body := cur
+			cur = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			cur[len(cur)-1].body = body
+		case strings.HasPrefix(expr, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(expr, "range ")))
+			if err != nil {
+				return nil, err
+			}
+
+			cur = append(cur, jpNode{path: path, isRange: true})
+			stack = append(stack, cur)
+			cur = nil
+		case strings.HasPrefix(expr, `"`) || strings.HasPrefix(expr, "'"):
+			s, err := unquote(expr)
+			if err != nil {
+				return nil, fmt.Errorf("jsonpath: bad string %s: %w", expr, err)
+			}
+
+			cur = append(cur, jpNode{text: s})
+		default:
+			path, err := parsePath(expr)
+			if err != nil {
+				return nil, err
+			}
+
+			cur = append(cur, jpNode{path: path, expr: true})
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("jsonpath: {range} without {end}")
+	}
+
+	return &jsonPath{nodes: cur}, nil
+}
+
+// closingBrace returns the index of the '}' matching the '{' at open,
+// skipping over quoted strings.
+func closingBrace(s string, open int) int {
+	var quote byte
+
+	for i := open + 1; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unquote(s string) (string, error) {
+	if strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'") && len(s) >= 2 {
+		return strings.ReplaceAll(s[1:len(s)-1], `\'`, "'"), nil
+	}
+
+	return strconv.Unquote(s)
+}
+
+func parsePath(p string) ([]jpStep, error) {
+	orig := p
+	p = strings.TrimPrefix(strings.TrimPrefix(p, "$"), "@")
+
+	if p != "" && p[0] != '.' && p[0] != '[' {
+		p = "." + p
+	}
+
+	var steps []jpStep
+
+	for len(p) > 0 {
+		switch {
+		case strings.HasPrefix(p, ".."):
+			p = p[2:]
+
+			if strings.HasPrefix(p, "[") {
+				step, rest, err := parseBracket(p)
+				if err != nil {
+					return nil, fmt.Errorf("jsonpath: %q: %w", orig, err)
+				}
+
+				step.recursive = true
+				steps = append(steps, step)
+				p = rest
+
+				continue
+			}
+
+			name, rest := pathName(p)
+			step := jpStep{kind: jpField, names: []string{name}, recursive: true}
+
+			if name == "*" {
+				step = jpStep{kind: jpWildcard, recursive: true}
+			}
+
+			steps = append(steps, step)
+			p = rest
+		case p[0] == '.':
+			name, rest := pathName(p[1:])
+			p = rest
+
+			switch name {
+			case "":
+				// A bare "." selects the current value.
+			case "*":
+				steps = append(steps, jpStep{kind: jpWildcard})
+			default:
+				steps = append(steps, jpStep{kind: jpField, names: []string{name}})
+			}
+		case p[0] == '[':
+			step, rest, err := parseBracket(p)
+			if err != nil {
+				return nil, fmt.Errorf("jsonpath: %q: %w", orig, err)
+			}
+
+			steps = append(steps, step)
+			p = rest
+		default:
+			return nil, fmt.Errorf("jsonpath: %q: unexpected %q", orig, p)
+		}
+	}
+
+	return steps, nil
+}
+
+func pathName(p string) (string, string) {
+	i := strings.IndexAny(p, ".[")
+	if i < 0 {
+		return p, ""
+	}
+
+	return p[:i], p[i:]
+}
+
+func parseBracket(p string) (jpStep, string, error) {
+	end := closingBracket(p)
+	if end < 0 {
+		return jpStep{}, "", fmt.Errorf("unclosed '['")
+	}
+
+	inner := strings.TrimSpace(p[1:end])
+	rest := p[end+1:]
+
+	switch {
+	case inner == "*":
+		return jpStep{kind: jpWildcard}, rest, nil
+	case strings.HasPrefix(inner, "?(") && strings.HasSuffix(inner, ")"):
+		cond, err := parseCond(strings.TrimSpace(inner[2 : len(inner)-1]))
+		if err != nil {
+			return jpStep{}, "", err
+		}
+
+		return jpStep{kind: jpFilter, filter: cond}, rest, nil
+	case strings.HasPrefix(inner, "'") || strings.HasPrefix(inner, `"`):
+		var names []string
+
+		for _, part := range splitUnquoted(inner, ',') {
+			name, err := unquote(strings.TrimSpace(part))
+			if err != nil {
+				return jpStep{}, "", fmt.Errorf("bad field name %s", part)
+			}
+
+			names = append(names, name)
+		}
+
+		return jpStep{kind: jpField, names: names}, rest, nil
+	case strings.Contains(inner, ":"):
+		lo, hi, _ := strings.Cut(inner, ":")
+		step := jpStep{kind: jpSlice}
+
+		for _, b := range []struct {
+			s   string
+			dst **int
+		}{{lo, &step.start}, {hi, &step.end}} {
+			if s := strings.TrimSpace(b.s); s != "" {
+				n, err := strconv.Atoi(s)
+				if err != nil {
+					return jpStep{}, "", fmt.Errorf("bad slice bound %q", s)
+				}
+
+				*b.dst = &n
+			}
+		}
+
+		return step, rest, nil
+	}
+
+	n, err := strconv.Atoi(inner)
+	if err != nil {
+		return jpStep{}, "", fmt.Errorf("bad index %q", inner)
+	}
+
+	return jpStep{kind: jpIndex, index: n}, rest, nil
+}
+
+func closingBracket(p string) int {
+	var (
+		quote byte
+		depth int
+	)
+
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+var jpOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseCond(s string) (*jpCond, error) {
+	lhs, op, rhs := cutOperator(s)
+
+	path, err := parsePath(strings.TrimSpace(lhs))
+	if err != nil {
+		return nil, err
+	}
+
+	if op == "" {
+		return &jpCond{path: path}, nil
+	}
+
+	value, err := parseLiteral(strings.TrimSpace(rhs))
+	if err != nil {
+		return nil, err
+	}
+
+	return &jpCond{path: path, op: op, value: value}, nil
+}
+
+// cutOperator splits a filter at its first comparison operator outside
+// quotes, so literals such as 'a==b' stay whole.
+func cutOperator(s string) (string, string, string) {
+	var quote byte
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		default:
+			for _, op := range jpOperators {
+				if strings.HasPrefix(s[i:], op) {
+					return s[:i], op, s[i+len(op):]
+				}
+			}
+		}
+	}
+
+	return s, "", ""
+}
+
+// splitUnquoted splits s at each sep outside quotes.
+func splitUnquoted(s string, sep byte) []string {
+	var (
+		parts []string
+		quote byte
+		start int
+	)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+func parseLiteral(s string) (any, error) {
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	if strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`) {
+		return unquote(s)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("bad filter value %q", s)
+	}
+
+	return f, nil
+}
+
+func (j *jsonPath) execute(w io.Writer, data any) error {
+	return executeNodes(w, j.nodes, data)
+}
+
+func executeNodes(w io.Writer, nodes []jpNode, data any) error {
+	for _, n := range nodes {
+		switch {
+		case n.isRange:
+			for _, v := range evalPath(n.path, []any{data}) {
+				if err := executeNodes(w, n.body, v); err != nil {
+					return err
+				}
+			}
+		case n.expr:
+			values := evalPath(n.path, []any{data})
+			text := make([]string, 0, len(values))
+
+			for _, v := range values {
+				s, err := jpString(v)
+				if err != nil {
+					return err
+				}
+
+				text = append(text, s)
+			}
+
+			if _, err := io.WriteString(w, strings.Join(text, " ")); err != nil {
+				return err
+			}
+		default:
+			if _, err := io.WriteString(w, n.text); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func evalPath(steps []jpStep, values []any) []any {
+	for _, step := range steps {
+		if step.recursive {
+			values = descendants(values)
+		}
+
+		var next []any
+
+		for _, v := range values {
+			next = append(next, evalStep(step, v)...)
+		}
+
+		values = next
+	}
+
+	return values
+}
+
+func descendants(values []any) []any {
+	var all []any
+
+	for _, v := range values {
+		all = append(all, v)
+		all = append(all, descendants(children(v))...)
+	}
+
+	return all
+}
+
+func children(v any) []any {
+	switch t := v.(type) {
+	case []any:
+		return t
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+
+		slices.Sort(keys)
+
+		out := make([]any, 0, len(keys))
+		for _, k := range keys {
+			out = append(out, t[k])
+		}
+
+		return out
+	}
+
+	return nil
+}
+
+func evalStep(step jpStep, v any) []any {
+	switch step.kind {
+	case jpField:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		var out []any
+
+		for _, name := range step.names {
+			if f, ok := m[name]; ok {
+				out = append(out, f)
+			}
+		}
+
+		return out
+	case jpWildcard:
+		return children(v)
+	case jpIndex:
+		a, ok := v.([]any)
+		if !ok {
+			return nil
+		}
+
+		i := step.index
+		if i < 0 {
+			i += len(a)
+		}
+
+		if i < 0 || i >= len(a) {
+			return nil
+		}
+
+		return []any{a[i]}
+	case jpSlice:
+		a, ok := v.([]any)
+		if !ok {
+			return nil
+		}
+
+		lo, hi := bound(step.start, 0, len(a)), bound(step.end, len(a), len(a))
+		if lo >= hi {
+			return nil
+		}
+
+		return a[lo:hi]
+	case jpFilter:
+		var out []any
+
+		for _, item := range children(v) {
+			if step.filter.match(item) {
+				out = append(out, item)
+			}
+		}
+
+		return out
+	}
+
+	return nil
+}
+
+func bound(p *int, def, n int) int {
+	if p == nil {
+		return def
+	}
+
+	i := *p
+	if i < 0 {
+		i += n
+	}
+
+	return max(0, min(i, n))
+}
+
+func (c *jpCond) match(item any) bool {
+	values := evalPath(c.path, []any{item})
+
+	if c.op == "" {
+		return len(values) > 0 && values[0] != nil && values[0] != false
+	}
+
+	if len(values) == 0 {
+		return c.op == "!="
+	}
+
+	cmp, ok := compare(values[0], c.value)
+	if !ok {
+		return c.op == "!="
+	}
+
+	switch c.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+
+	return false
+}
+
+// compare orders a and b when they are both numbers, strings, or booleans.
+func compare(a, b any) (int, bool) {
+	if n, ok := a.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+
+		a = f
+	}
+
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			return cmpFloat(x, y), true
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok && x == y {
+			return 0, true
+		} else if ok {
+			return 1, true
+		}
+	case nil:
+		if b == nil {
+			return 0, true
+		}
+	}
+
+	return 0, false
+}
+
+func cmpFloat(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+
+	return 0
+}
+
+// jpString prints scalars as plain text and objects or lists as JSON.
+func jpString(v any) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case json.Number:
+		return t.String(), nil
+	case nil:
+		return "", nil
+	case map[string]any, []any:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", fmt.Errorf("json marshal: %w", err)
+		}
+
+		return string(b), nil
+	}
+
+	return fmt.Sprint(v), nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const jsonPathData = `{
+  "items": [
+    {"name": "web1", "vmid": 100, "status": "running", "tags": ["prod", "web"], "net": {"ip": "10.0.0.1"}},
+    {"name": "a==b", "vmid": 101, "status": "stopped", "tags": [], "net": {"ip": "10.0.0.2"}},
+    {"name": "<=", "vmid": 102, "status": "running", "template": true}
+  ],
+  "meta": {"count": 3, "a,b": "comma", "it's": "quote", "x]y": "bracket"}
+}`
+
+func TestJSONPath(t *testing.T) {
+	data, err := jsonData(json.RawMessage(jsonPathData))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{"field", "{.meta.count}", "3"},
+		{"no braces", ".meta.count", "3"},
+		{"dollar root", "{$.meta.count}", "3"},
+		{"missing field", "{.meta.nope}", ""},
+		{"object as json", "{.items[0].tags}", `["prod","web"]`},
+		{"wildcard", "{.items[*].name}", "web1 a==b <="},
+		{"index", "{.items[1].vmid}", "101"},
+		{"negative index", "{.items[-1].vmid}", "102"},
+		{"index out of range", "{.items[3].vmid}{.items[-4].vmid}", ""},
+		{"slice", "{.items[0:2].vmid}", "100 101"},
+		{"slice open end", "{.items[1:].vmid}", "101 102"},
+		{"slice open start", "{.items[:1].vmid}", "100"},
+		{"slice negative", "{.items[-2:].vmid}", "101 102"},
+		{"slice negative end", "{.items[:-1].vmid}", "100 101"},
+		{"slice empty", "{.items[2:1].vmid}", ""},
+		{"filter ==", `{.items[?(@.status=="running")].vmid}`, "100 102"},
+		{"filter !=", `{.items[?(@.status!="running")].vmid}`, "101"},
+		{"filter <", "{.items[?(@.vmid<101)].vmid}", "100"},
+		{"filter <=", "{.items[?(@.vmid<=101)].vmid}", "100 101"},
+		{"filter >", "{.items[?(@.vmid>100)].vmid}", "101 102"},
+		{"filter >=", "{.items[?(@.vmid >= 102)].vmid}", "102"},
+		{"filter bool", "{.items[?(@.template==true)].vmid}", "102"},
+		{"filter exists", "{.items[?(@.template)].vmid}", "102"},
+		{"filter missing field !=", "{.items[?(@.template!=true)].vmid}", "100 101"},
+		{"filter operator in single quotes", "{.items[?(@.name!='a==b')].vmid}", "100 102"},
+		{"filter operator in double quotes", `{.items[?(@.name=="<=")].vmid}`, "102"},
+		{"filter == in quotes", "{.items[?(@.name=='a==b')].vmid}", "101"},
+		{"recursive field", "{..ip}", "10.0.0.1 10.0.0.2"},
+		{"recursive under path", "{.items..name}", "web1 a==b <="},
+		{"recursive wildcard", "{.items[0].net..*}", "10.0.0.1"},
+		{"recursive bracket", "{..['ip']}", "10.0.0.1 10.0.0.2"},
+		{"range", `{range .items[*]}{.name}{"\t"}{.vmid}{"\n"}{end}`, "web1\t100\na==b\t101\n<=\t102\n"},
+		{"nested range", `{range .items[*]}{range .tags[*]}{@}{","}{end}{end}`, "prod,web,"},
+		{"range with text", "vmids:{range .items[*]} {.vmid}{end}", "vmids: 100 101 102"},
+		{"quoted field", "{.meta['a,b']}", "comma"},
+		{"double quoted field", `{.meta["a,b"]}`, "comma"},
+		{"escaped quote in field", `{.meta['it\'s']}`, "quote"},
+		{"bracket in field", "{.meta['x]y']}", "bracket"},
+		{"several fields", "{.meta['a,b','count']}", "comma 3"},
+		{"brace in literal", `{"}"}{'{'}`, "}{"},
+		{"literal escapes", `{"a\tb"}`, "a\tb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jp, err := parseJSONPath(tt.tmpl)
+			if err != nil {
+				t.Fatalf("parseJSONPath(%q): %v", tt.tmpl, err)
+			}
+
+			var b strings.Builder
+
+			if err := jp.execute(&b, data); err != nil {
+				t.Fatalf("execute(%q): %v", tt.tmpl, err)
+			}
+
+			if got := b.String(); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPathErrors(t *testing.T) {
+	tests := []string{
+		"{.items",
+		"{range .items[*]}{.name}",
+		"{.name}{end}",
+		"{.items[}",
+		"{.items[x]}",
+		"{.items[1:x]}",
+		"{.items[?(@.vmid>abc)]}",
+		`{.items['a]}`,
+		`{"unterminated}`,
+	}
+
+	for _, tmpl := range tests {
+		if _, err := parseJSONPath(tmpl); err == nil {
+			t.Errorf("parseJSONPath(%q) succeeded, want an error", tmpl)
+		}
+	}
+}
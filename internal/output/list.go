@@ -0,0 +1,495 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"github.com/spf13/viper"
+)
+
+const (
+	KeyColumns = "columns"
+	KeySortBy  = "sort_by"
+	KeyReverse = "reverse"
+)
+
+// Column is one column of a list. Field is the JSON field it shows, which
+// --columns and --sort-by accept in place of the header. A column without
+// a Field sorts by SortKey, or by its text. The Key column identifies a row
+// and is all --quiet prints.
+type Column[T any] struct {
+	Header  string
+	Field   string
+	Value   func(T) string
+	SortKey func(T) any
+	Wide    bool // only shown with -o wide
+	Key     bool
+}
+
+// List prints items as a table, or as data in a structured format. It
+// orders them by --sort-by and --reverse and picks the table columns from
+// --columns, adding the Wide columns with -o wide. With --quiet it prints
+// only the key of each item, one per line.
+func List[T any](items []T, columns []Column[T]) error {
+	if err := Sort(items, columns); err != nil {
+		return err
+	}
+
+	return Render(items, columns)
+}
+
+// Render prints items like List but in their current order, for callers
+// that sort once and print in several parts.
+func Render[T any](items []T, columns []Column[T]) error {
+	if IsQuiet() {
+		key := keyColumn(columns)
+
+		for _, item := range items {
+			fmt.Println(color.Strip(key.Value(item)))
+		}
+
+		return nil
+	}
+
+	if IsStructured() {
+		return Print(items)
+	}
+
+	selected, err := selectColumns(columns)
+	if err != nil {
+		return err
+	}
+
+	var data []any
+
+	for _, c := range selected {
+		if c.Value == nil {
+			if data, err = itemData(items); err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	headers := make([]string, len(selected))
+
+	for i, c := range selected {
+		headers[i] = c.Header
+	}
+
+	rows := make([][]string, len(items))
+
+	for i, item := range items {
+		row := make([]string, len(selected))
+
+		for j, c := range selected {
+			if c.Value != nil {
+				row[j] = c.Value(item)
+			} else {
+				row[j] = cell(evalPath(c.path, []any{data[i]}))
+			}
+		}
+
+		rows[i] = row
+	}
+
+	Table(headers, rows)
+
+	return nil
+}
+
+// Sort orders items in place by --sort-by, comparing numbers numerically
+// and digit runs inside strings by value. Items without the field go last.
+// --reverse flips the order.
+func Sort[T any](items []T, columns []Column[T]) error {
+	field := viper.GetString(KeySortBy)
+	reverse := viper.GetBool(KeyReverse)
+
+	if field == "" {
+		if reverse {
+			slices.Reverse(items)
+		}
+
+		return nil
+	}
+
+	keys := make([]any, len(items))
+
+	if c, ok := findColumn(field, columns); ok && c.Field == "" {
+		for i, item := range items {
+			if c.SortKey != nil {
+				keys[i] = c.SortKey(item)
+			} else {
+				keys[i] = color.Strip(c.Value(item))
+			}
+		}
+	} else {
+		if err := checkName("--sort-by", field, columns); err != nil {
+			return err
+		}
+
+		path, err := parsePath(fieldPath(field, columns))
+		if err != nil {
+			return fmt.Errorf("--sort-by: %w", err)
+		}
+
+		data, err := itemData(items)
+		if err != nil {
+			return err
+		}
+
+		for i := range items {
+			if v := evalPath(path, []any{data[i]}); len(v) > 0 {
+				keys[i] = v[0]
+			}
+		}
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		ka, kb := keys[a], keys[b]
+
+		switch {
+		case ka == nil && kb == nil:
+			return 0
+		case ka == nil:
+			return 1
+		case kb == nil:
+			return -1
+		}
+
+		c := compareValues(ka, kb)
+		if reverse {
+			return -c
+		}
+
+		return c
+	})
+
+	sorted := make([]T, len(items))
+	for i, j := range order {
+		sorted[i] = items[j]
+	}
+
+	copy(items, sorted)
+
+	return nil
+}
+
+// listColumn is a column chosen for output. Columns named by --columns that
+// the command does not define read the field straight from the JSON data.
+type listColumn[T any] struct {
+	Column[T]
+	path []jpStep
+}
+
+func selectColumns[T any](columns []Column[T]) ([]listColumn[T], error) {
+	wide := Format() == "wide"
+	spec := viper.GetString(KeyColumns)
+	out := []listColumn[T]{}
+
+	// "+field,..." adds to the default columns instead of replacing them.
+	add, extend := strings.CutPrefix(spec, "+")
+
+	if spec == "" || extend {
+		for _, c := range columns {
+			if !c.Wide || wide {
+				out = append(out, listColumn[T]{Column: c})
+			}
+		}
+
+		spec = add
+	}
+
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "+")
+		if name == "" {
+			continue
+		}
+
+		if c, ok := findColumn(name, columns); ok {
+			out = append(out, listColumn[T]{Column: c})
+			continue
+		}
+
+		if err := checkName("--columns", name, columns); err != nil {
+			return nil, err
+		}
+
+		path, err := parsePath(name)
+		if err != nil {
+			return nil, fmt.Errorf("--columns: %w", err)
+		}
+
+		header := strings.ToUpper(strings.TrimLeft(name, "$@."))
+		out = append(out, listColumn[T]{Column: Column[T]{Header: header, Field: name}, path: path})
+	}
+
+	return out, nil
+}
+
+// checkName rejects a --columns or --sort-by name that is neither a column
+// nor a JSON field of the items, listing the names that would work.
+func checkName[T any](flag, name string, columns []Column[T]) error {
+	if _, ok := findColumn(name, columns); ok {
+		return nil
+	}
+
+	fields := jsonFields(reflect.TypeFor[T]())
+	if fields == nil {
+		return nil
+	}
+
+	root := strings.TrimLeft(name, "$@.")
+	if i := strings.IndexAny(root, ".["); i >= 0 {
+		root = root[:i]
+	}
+
+	if root == "" || root == "*" || slices.Contains(fields, root) {
+		return nil
+	}
+
+	var names []string
+
+	for _, c := range columns {
+		if c.Field != "" {
+			names = append(names, c.Field)
+		} else {
+			names = append(names, c.Header)
+		}
+	}
+
+	for _, f := range fields {
+		if !slices.Contains(names, f) {
+			names = append(names, f)
+		}
+	}
+
+	return fmt.Errorf("%s: unknown column %q; valid names: %s", flag, name, strings.Join(names, ", "))
+}
+
+// jsonFields lists the JSON field names of a struct type, including those
+// of embedded structs, or nil for other types.
+func jsonFields(t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	names := []string{}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+		switch {
+		case name == "-":
+			continue
+		case f.Anonymous && name == "":
+			names = append(names, jsonFields(f.Type)...)
+			continue
+		case !f.IsExported():
+			continue
+		case name == "":
+			name = f.Name
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// keyColumn returns the column marked Key, or the first column.
+func keyColumn[T any](columns []Column[T]) Column[T] {
+	for _, c := range columns {
+		if c.Key {
+			return c
+		}
+	}
+
+	return columns[0]
+}
+
+func findColumn[T any](name string, columns []Column[T]) (Column[T], bool) {
+	for _, c := range columns {
+		if strings.EqualFold(name, c.Field) || strings.EqualFold(name, c.Header) {
+			return c, true
+		}
+	}
+
+	return Column[T]{}, false
+}
+
+// fieldPath maps a column header to its JSON field so --sort-by accepts
+// either.
+func fieldPath[T any](name string, columns []Column[T]) string {
+	if c, ok := findColumn(name, columns); ok && c.Field != "" {
+		return c.Field
+	}
+
+	return name
+}
+
+func itemData[T any](items []T) ([]any, error) {
+	data := make([]any, len(items))
+
+	for i, item := range items {
+		d, err := jsonData(item)
+		if err != nil {
+			return nil, err
+		}
+
+		data[i] = d
+	}
+
+	return data, nil
+}
+
+// cell renders field values for a table: lists of scalars are joined with
+// commas and objects are printed as JSON.
+func cell(values []any) string {
+	if len(values) == 1 {
+		if list, ok := values[0].([]any); ok {
+			values = list
+		}
+	}
+
+	text := make([]string, 0, len(values))
+
+	for _, v := range values {
+		s, err := jpString(v)
+		if err != nil {
+			s = fmt.Sprint(v)
+		}
+
+		text = append(text, s)
+	}
+
+	return strings.Join(text, ",")
+}
+
+// compareValues orders numbers before strings, numbers by value, and
+// strings naturally so "vm2" sorts before "vm10".
+func compareValues(a, b any) int {
+	fa, aNum := number(a)
+	fb, bNum := number(b)
+
+	switch {
+	case aNum && bNum:
+		return cmpFloat(fa, fb)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+
+	sa, _ := jpString(a)
+	sb, _ := jpString(b)
+
+	return naturalCompare(sa, sb)
+}
+
+func number(v any) (float64, bool) {
+	switch t := v.(type) {
+	case json.Number:
+		f, err := t.Float64()
+		return f, err == nil
+	case float64:
+		return t, true
+	}
+
+	return 0, false
+}
+
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		da, db := digitRun(a), digitRun(b)
+
+		if da > 0 && db > 0 {
+			na := strings.TrimLeft(a[:da], "0")
+			nb := strings.TrimLeft(b[:db], "0")
+
+			if c := len(na) - len(nb); c != 0 {
+				return c
+			}
+
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+
+			a, b = a[da:], b[db:]
+
+			continue
+		}
+
+		if c := strings.Compare(strings.ToLower(a[:1]), strings.ToLower(b[:1])); c != 0 {
+			return c
+		}
+
+		a, b = a[1:], b[1:]
+	}
+
+	return len(a) - len(b)
+}
+
+func digitRun(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	return i
+}
+
+// Shows reports whether the table will show or sort by field, so commands
+// can skip fetching data nobody asked for.
+func Shows[T any](field string, columns []Column[T]) bool {
+	if strings.EqualFold(fieldPath(viper.GetString(KeySortBy), columns), field) {
+		return true
+	}
+
+	if IsStructured() {
+		return false
+	}
+
+	selected, err := selectColumns(columns)
+	if err != nil {
+		return false
+	}
+
+	for _, c := range selected {
+		if strings.EqualFold(strings.TrimLeft(c.Field, "$@."), field) {
+			return true
+		}
+	}
+
+	return false
+}
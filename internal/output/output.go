@@ -18,15 +18,18 @@ package output
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
-	"text/tabwriter"
+	"unicode/utf8"
 
 	"github.com/dcjulian29/proxmoxctl/internal/color"
 	"github.com/spf13/viper"
 )
 
-const KeyOutputFormat = "output_format"
+const (
+	KeyOutputFormat = "output_format"
+	KeyNoHeaders    = "no_headers"
+	KeyQuiet        = "quiet"
+)
 
 func Format() string {
 	f := viper.GetString(KeyOutputFormat)
@@ -35,11 +38,48 @@ func Format() string {
 		return "table"
 	}
 
-	return strings.ToLower(f)
+	name, _, _ := strings.Cut(f, "=")
+
+	return strings.ToLower(name)
 }
 
-func IsJSON() bool {
-	return Format() == "json"
+// IsStructured reports whether results are printed as data (json, yaml, or
+// a template) instead of a table.
+func IsStructured() bool {
+	switch Format() {
+	case "json", "yaml":
+		return true
+	}
+
+	return IsTemplate()
+}
+
+// IsDelimited reports whether tables are written as CSV or TSV.
+func IsDelimited() bool {
+	switch Format() {
+	case "csv", "tsv":
+		return true
+	}
+
+	return false
+}
+
+// IsBare reports whether output is data only: structured formats, CSV,
+// TSV, and --quiet leave out titles, rules, and "No ... found." notes.
+func IsBare() bool {
+	return IsStructured() || IsDelimited() || IsQuiet()
+}
+
+// Print writes v in the selected structured format.
+func Print(v interface{}) error {
+	switch {
+	case Format() == "yaml":
+		return YAML(v)
+	case IsTemplate():
+		return Template(v)
+	}
+
+	return JSON(v)
 }
 
 func JSON(v interface{}) error {
@@ -54,35 +94,57 @@ func JSON(v interface{}) error {
 	return nil
 }
 
+// Table writes rows as aligned columns, or as CSV or TSV when selected.
+// The header row is left out with --no-headers.
 func Table(headers []string, rows [][]string) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if viper.GetBool(KeyNoHeaders) {
+		headers = nil
+	}
 
-	_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", 60))
+	switch Format() {
+	case "csv":
+		writeCSV(headers, rows)
+		return
+	case "tsv":
+		writeTSV(headers, rows)
+		return
+	}
 
-	for _, row := range rows {
-		_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+	lines := rows
+	if headers != nil {
+		lines = append([][]string{headers}, rows...)
 	}
 
-	_ = w.Flush()
-}
+	// Columns are padded by visible width so colored cells line up.
+	widths := map[int]int{}
 
-func Success(msg string) {
-	if IsJSON() {
-		b, _ := json.Marshal(map[string]string{"status": "ok", "message": msg})
-		fmt.Println(string(b))
-		return
+	for _, row := range lines {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(color.Strip(cell)))
+		}
 	}
 
-	fmt.Println(color.Green("✓ ") + msg)
-}
+	for n, row := range lines {
+		var b strings.Builder
 
-func Aborted(reason string) {
-	if IsJSON() {
-		b, _ := json.Marshal(map[string]string{"status": "aborted", "message": reason})
-		fmt.Println(string(b))
-		return
+		for i, cell := range row {
+			b.WriteString(cell)
+
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(color.Strip(cell))+2))
+			}
+		}
+
+		fmt.Println(b.String())
+
+		if n == 0 && headers != nil {
+			fmt.Println(strings.Repeat("-", 60))
+		}
 	}
+}
 
-	fmt.Println(color.Red("⨯ ") + reason)
+// IsQuiet reports whether --quiet limits output to identifiers: the key
+// column of lists and the UPID of queued tasks.
+func IsQuiet() bool {
+	return viper.GetBool(KeyQuiet)
 }
@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+)
+
+// Result statuses.
+const (
+	StatusOK        = "ok"
+	StatusAborted   = "aborted"
+	StatusCancelled = "cancelled"
+	StatusError     = "error"
+)
+
+// Result is the outcome of a command in structured output. Every mutating
+// command, and every failure, prints exactly one.
+type Result struct {
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	UPID     string `json:"upid,omitempty"`
+	Node     string `json:"node,omitempty"`
+	VMID     int    `json:"vmid,omitempty"`
+	ExitCode int    `json:"exit_code,omitempty"`
+	Error    any    `json:"error,omitempty"`
+	Hint     string `json:"hint,omitempty"`
+}
+
+// Done reports a successful change. With --quiet only the UPID of a queued
+// task is printed.
+func Done(r Result) {
+	if IsQuiet() {
+		if r.UPID != "" {
+			fmt.Println(r.UPID)
+		}
+
+		return
+	}
+
+	if r.Status == "" {
+		r.Status = StatusOK
+	}
+
+	if IsStructured() {
+		Report(r)
+		return
+	}
+
+	fmt.Println(color.Green("✓ ") + r.Message)
+}
+
+func Success(msg string) {
+	Done(Result{Message: msg})
+}
+
+func Cancelled(reason string) {
+	if IsStructured() {
+		Report(Result{Status: StatusCancelled, Message: reason})
+		return
+	}
+
+	fmt.Println(color.Red("⨯ ") + reason)
+}
+
+func Aborted(reason string) {
+	if IsStructured() {
+		Report(Result{Status: StatusAborted, Message: reason})
+		return
+	}
+
+	fmt.Println(color.Red("⨯ ") + reason)
+}
+
+// Report prints r as a one-line JSON object, or hands it to the selected
+// YAML or template format.
+func Report(r Result) {
+	if Format() != "json" {
+		_ = Print(r)
+		return
+	}
+
+	b, _ := json.Marshal(r)
+	fmt.Println(string(b))
+}
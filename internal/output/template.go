@@ -0,0 +1,119 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/spf13/viper"
+)
+
+// IsTemplate reports whether output is rendered through a go-template or
+// jsonpath expression.
+func IsTemplate() bool {
+	switch Format() {
+	case "go-template", "go-template-file", "jsonpath":
+		return true
+	}
+
+	return false
+}
+
+// Template renders v with the go-template, go-template-file, or jsonpath
+// expression given to --output. Templates see the same fields as -o json.
+func Template(v interface{}) error {
+	arg, ok := formatArg()
+	if !ok || arg == "" {
+		return fmt.Errorf("-o %s requires a value, e.g. -o %s=...", Format(), Format())
+	}
+
+	data, err := jsonData(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	switch Format() {
+	case "jsonpath":
+		jp, err := parseJSONPath(arg)
+		if err != nil {
+			return err
+		}
+
+		if err := jp.execute(&buf, data); err != nil {
+			return fmt.Errorf("jsonpath: %w", err)
+		}
+	default:
+		text := arg
+
+		if Format() == "go-template-file" {
+			b, err := os.ReadFile(arg)
+			if err != nil {
+				return fmt.Errorf("reading template: %w", err)
+			}
+
+			text = string(b)
+		}
+
+		t, err := template.New("output").Funcs(sprig.TxtFuncMap()).Parse(text)
+		if err != nil {
+			return fmt.Errorf("go-template: %w", err)
+		}
+
+		if err := t.Execute(&buf, data); err != nil {
+			return fmt.Errorf("go-template: %w", err)
+		}
+	}
+
+	_, err = os.Stdout.Write(buf.Bytes())
+
+	return err
+}
+
+// jsonData converts v to the generic maps and slices its JSON form decodes
+// to. Numbers stay json.Number so they print exactly as in -o json.
+func jsonData(v interface{}) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("json marshal: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var data any
+
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+
+	return data, nil
+}
+
+// formatArg returns the text after "=" in --output, e.g. the template in
+// -o go-template={{.name}}.
+func formatArg() (string, bool) {
+	_, arg, ok := strings.Cut(viper.GetString(KeyOutputFormat), "=")
+
+	return arg, ok
+}
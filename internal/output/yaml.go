@@ -0,0 +1,69 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// YAML writes v as a YAML document. The value is encoded through JSON first
+// so field names, omitempty, and custom marshalers match the JSON output.
+func YAML(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("yaml marshal: %w", err)
+	}
+
+	// JSON decodes as flow style; clear it so the document is block style.
+	blockStyle(&doc)
+
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("yaml marshal: %w", err)
+	}
+
+	return enc.Close()
+}
+
+var yaml11Bools = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "on": true, "off": true,
+}
+
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+
+	// YAML 1.1 readers take these for booleans, so keep them quoted.
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && yaml11Bools[strings.ToLower(n.Value)] {
+		n.Style = yaml.DoubleQuotedStyle
+	}
+
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"syscall"
+
+	"github.com/dcjulian29/proxmoxctl/internal/color"
+	"golang.org/x/term"
+)
+
+// Secret prints prompt and reads a line without echoing it. When stdin is
+// not a terminal the line is read from reader instead.
+func Secret(reader *bufio.Reader, prompt string) string {
+	fmt.Print(color.Green(prompt))
+
+	b, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		// Fallback for non-TTY environments
+		line, _ := reader.ReadString('\n')
+		b = []byte(line)
+	}
+
+	fmt.Println()
+
+	return strings.TrimSpace(string(b))
+}
@@ -16,9 +16,13 @@ limitations under the License.
 package settings
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/dcjulian29/proxmoxctl/internal/color"
 	"github.com/spf13/viper"
@@ -28,45 +32,263 @@ const (
 	KeyServerName = "server_name"
 	KeyServerURL  = "server_url"
 	KeyAPIToken   = "api_token"
+	KeyUsername   = "username"
+
+	KeyDefaultNode = "default_node"
+
+	KeyAPITokenCommand = "api_token_command"
+	KeyAPITokenFile    = "api_token_file"
+
+	KeyTLSCAFile      = "tls_ca_file"
+	KeyTLSFingerprint = "tls_fingerprint"
+	KeyTLSInsecure    = "tls_insecure"
+
+	// KeyContext is the --context flag (or PROXMOX_CONTEXT); it overrides
+	// KeyCurrentContext for a single run.
+	KeyContext        = "context"
+	KeyContexts       = "contexts"
+	KeyCurrentContext = "current_context"
 )
 
-func Save() error {
+// TokenKeys are the places an API token can come from. A context keeps
+// only one of them, since APIToken stops at the first that is set.
+var TokenKeys = []string{KeyAPIToken, KeyAPITokenFile, KeyAPITokenCommand}
+
+// connectionKeys describe one cluster. A context replaces all of them, so a
+// context never inherits the top-level credentials of a different server.
+var connectionKeys = map[string]any{
+	KeyServerName:      "",
+	KeyServerURL:       "",
+	KeyAPIToken:        "",
+	KeyAPITokenCommand: "",
+	KeyAPITokenFile:    "",
+	KeyUsername:        "",
+	KeyDefaultNode:     "",
+	KeyTLSCAFile:       "",
+	KeyTLSFingerprint:  "",
+	KeyTLSInsecure:     false,
+}
+
+// IsConnectionKey reports whether key belongs to a context rather than to
+// the config file as a whole.
+func IsConnectionKey(key string) bool {
+	_, ok := connectionKeys[key]
+	return ok
+}
+
+// ErrNoContext is returned for a context name missing from the config file.
+var ErrNoContext = errors.New("context not found")
+
+var (
+	contextName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+	// active is the context UseContext last applied.
+	active string
+
+	// contextErr is reported by RequireConfig rather than at startup, so the
+	// config commands can still repair a bad current_context.
+	contextErr error
+)
+
+// CurrentContext returns the context in use, or "" when the flat keys at the
+// top of the config file are used.
+func CurrentContext() string {
+	return active
+}
+
+// SelectedContext returns the context chosen by --context, PROXMOX_CONTEXT,
+// or current_context, in that order.
+func SelectedContext() string {
+	if name := viper.GetString(KeyContext); name != "" {
+		return strings.ToLower(name)
+	}
+
+	return strings.ToLower(viper.GetString(KeyCurrentContext))
+}
+
+// Contexts returns the configured context names, sorted.
+func Contexts() []string {
+	var names []string
+
+	for name := range viper.GetStringMap(KeyContexts) {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// ContextKey returns the config key that holds key inside context name, or
+// key itself when name is "".
+func ContextKey(name, key string) string {
+	if name == "" {
+		return key
+	}
+
+	return KeyContexts + "." + name + "." + key
+}
+
+// ValidateContextName rejects names viper cannot store as a single key.
+func ValidateContextName(name string) error {
+	if !contextName.MatchString(name) {
+		return fmt.Errorf("invalid context name %q — use lowercase letters, digits, '-' and '_'", name)
+	}
+
+	return nil
+}
+
+// UseContext reloads the config file and lays the keys of the named context
+// over the flat ones. Flags and environment variables still take precedence.
+func UseContext(name string) error {
+	contextErr = nil
+	active = ""
+
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return err
+		}
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	sub := viper.Sub(KeyContexts + "." + name)
+	if sub == nil {
+		contextErr = fmt.Errorf("%w: %q — see `proxmoxctl config get-contexts`", ErrNoContext, name)
+		return contextErr
+	}
+
+	active = name
+	cfg := sub.AllSettings()
+
+	for key, zero := range connectionKeys {
+		if _, ok := cfg[key]; !ok {
+			cfg[key] = zero
+		}
+	}
+
+	return viper.MergeConfigMap(cfg)
+}
+
+// ExpandHome replaces a leading "~/" in path with the user's home directory.
+func ExpandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("could not determine config directory: %w", err)
+		return "", err
+	}
+
+	return filepath.Join(home, rest), nil
+}
+
+// Dir returns ~/.config/proxmoxctl, creating it if needed.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine config directory: %w", err)
 	}
 
 	dir := filepath.Join(home, ".config", "proxmoxctl")
 	if err := os.MkdirAll(dir, 0700); err != nil {
-		return fmt.Errorf("could not create config directory: %w", err)
+		return "", fmt.Errorf("could not create config directory: %w", err)
 	}
 
-	configPath := filepath.Join(dir, "config.yml")
-	viper.SetConfigFile(configPath)
+	return dir, nil
+}
 
-	if err := viper.WriteConfig(); err != nil {
-		if os.IsNotExist(err) {
-			return viper.WriteConfigAs(configPath)
+// Update applies fn to the contents of the config file alone, without
+// flags, environment variables, or defaults, and writes the result back.
+// Use SetKey and UnsetKey to change nested keys.
+func Update(fn func(cfg map[string]any) error) error {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		dir, err := Dir()
+		if err != nil {
+			return err
 		}
 
+		path = filepath.Join(dir, "config.yml")
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not read config: %w", err)
+	}
+
+	cfg := v.AllSettings()
+	if err := fn(cfg); err != nil {
+		return err
+	}
+
+	// A fresh instance, because viper cannot remove keys it has read.
+	out := viper.New()
+
+	for k, val := range cfg {
+		out.Set(k, val)
+	}
+
+	if err := out.WriteConfigAs(path); err != nil {
 		return fmt.Errorf("could not write config: %w", err)
 	}
 
-	fmt.Println(color.Info("\nconfig saved to ", configPath))
+	fmt.Println(color.Teal("\nconfig saved to ", path))
 
 	return nil
 }
 
-func RequireConfig() error {
-	missing := []string{}
-	for _, key := range []string{KeyServerURL, KeyAPIToken} {
-		if viper.GetString(key) == "" {
-			missing = append(missing, key)
+// SetKey sets a dotted key such as "contexts.lab.server_url" in cfg.
+func SetKey(cfg map[string]any, key string, value any) {
+	parts := strings.Split(strings.ToLower(key), ".")
+
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := cfg[p].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			cfg[p] = next
 		}
+
+		cfg = next
+	}
+
+	cfg[parts[len(parts)-1]] = value
+}
+
+// UnsetKey removes a dotted key from cfg and reports whether it was there.
+func UnsetKey(cfg map[string]any, key string) bool {
+	parts := strings.Split(strings.ToLower(key), ".")
+
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := cfg[p].(map[string]any)
+		if !ok {
+			return false
+		}
+
+		cfg = next
+	}
+
+	_, ok := cfg[parts[len(parts)-1]]
+	delete(cfg, parts[len(parts)-1])
+
+	return ok
+}
+
+// RequireConfig checks for a server URL. Credentials come from api_token or
+// a cached login session and are checked when the client is created.
+func RequireConfig() error {
+	if contextErr != nil {
+		return contextErr
 	}
 
-	if len(missing) > 0 {
-		return fmt.Errorf("missing required config keys: %v — run `proxmoxctl config set` to configure", missing)
+	if viper.GetString(KeyServerURL) == "" {
+		return fmt.Errorf("missing required config keys: [%s] — run `proxmoxctl config set` to configure", KeyServerURL)
 	}
 
 	return nil
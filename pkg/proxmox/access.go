@@ -0,0 +1,128 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// AccessService manages users and groups under /access.
+type AccessService service
+
+// ChangePassword sets a user's password. params holds "userid" and
+// "password".
+func (s *AccessService) ChangePassword(ctx context.Context, params Params) error {
+	return s.client.Put(Idempotent(ctx), "/access/password", params, nil)
+}
+
+// CreateGroup adds a group. params holds at least "groupid".
+func (s *AccessService) CreateGroup(ctx context.Context, params Params) error {
+	return s.client.Post(ctx, "/access/groups", params, nil)
+}
+
+// CreateUser adds a user. params holds at least "userid".
+func (s *AccessService) CreateUser(ctx context.Context, params Params) error {
+	return s.client.Post(ctx, "/access/users", params, nil)
+}
+
+// DeleteGroup removes a group.
+func (s *AccessService) DeleteGroup(ctx context.Context, groupID string) error {
+	return s.client.Delete(ctx, fmt.Sprintf("/access/groups/%s", url.PathEscape(groupID)), nil)
+}
+
+// DeleteUser removes a user.
+func (s *AccessService) DeleteUser(ctx context.Context, userID string) error {
+	return s.client.Delete(ctx, fmt.Sprintf("/access/users/%s", url.PathEscape(userID)), nil)
+}
+
+// Group returns a group and its members. Proxmox omits the group ID from the
+// response, so it is filled in from groupID.
+func (s *AccessService) Group(ctx context.Context, groupID string) (*Group, error) {
+	var g Group
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/access/groups/%s", url.PathEscape(groupID)), &g); err != nil {
+		return nil, err
+	}
+
+	g.GroupID = groupID
+
+	return &g, nil
+}
+
+// Groups lists every group with its comment.
+func (s *AccessService) Groups(ctx context.Context) ([]Group, error) {
+	var groups []Group
+
+	if err := s.client.Get(ctx, "/access/groups", &groups); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// Permissions returns the effective privileges of the authenticated user or
+// token, limited to path and its children when path is not "".
+func (s *AccessService) Permissions(ctx context.Context, path string) (Permissions, error) {
+	var perms Permissions
+
+	q := url.Values{}
+	if path != "" {
+		q.Set("path", path)
+	}
+
+	if err := s.client.Get(ctx, withQuery("/access/permissions", q), &perms); err != nil {
+		return nil, err
+	}
+
+	return perms, nil
+}
+
+// UpdateGroup changes a group's comment.
+func (s *AccessService) UpdateGroup(ctx context.Context, groupID string, params Params) error {
+	return s.client.Put(Idempotent(ctx), fmt.Sprintf("/access/groups/%s", url.PathEscape(groupID)), params, nil)
+}
+
+// UpdateUser changes the given user properties.
+func (s *AccessService) UpdateUser(ctx context.Context, userID string, params Params) error {
+	return s.client.Put(Idempotent(ctx), fmt.Sprintf("/access/users/%s", url.PathEscape(userID)), params, nil)
+}
+
+// User returns a single user. Proxmox omits the user ID from the response,
+// so it is filled in from userID.
+func (s *AccessService) User(ctx context.Context, userID string) (*User, error) {
+	var u User
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/access/users/%s", url.PathEscape(userID)), &u); err != nil {
+		return nil, err
+	}
+
+	u.UserID = userID
+
+	return &u, nil
+}
+
+// Users lists every user.
+func (s *AccessService) Users(ctx context.Context) ([]User, error) {
+	var users []User
+
+	if err := s.client.Get(ctx, "/access/users", &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
@@ -0,0 +1,32 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import "net/http"
+
+// Authenticator adds credentials to an outgoing request.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// APIToken authenticates with a Proxmox API token of the form
+// "USER@REALM!TOKENID=SECRET".
+type APIToken string
+
+func (t APIToken) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "PVEAPIToken="+string(t))
+	return nil
+}
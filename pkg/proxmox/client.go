@@ -0,0 +1,283 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds a single request when no HTTP client is injected.
+const DefaultTimeout = 120 * time.Second
+
+// Client talks to the Proxmox VE REST API. Endpoints are grouped into
+// services; Get, Post, Put, and Delete remain available for anything the
+// services do not cover.
+type Client struct {
+	baseURL    string
+	auth       Authenticator
+	httpClient *http.Client
+	tlsConfig  *tls.Config
+	pin        string
+	timeout    time.Duration
+	retry      RetryPolicy
+
+	Access  *AccessService
+	Cluster *ClusterService
+	LXC     *GuestService
+	Nodes   *NodesService
+	Qemu    *GuestService
+	Storage *StorageService
+	Tasks   *TasksService
+}
+
+// Params is a request body. Keys are Proxmox API parameter names.
+type Params map[string]any
+
+type service struct {
+	client *Client
+}
+
+// New returns a client for the server at baseURL, e.g.
+// "https://pve.example.com:8006".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("proxmox: base URL is required")
+	}
+
+	c := &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		timeout: DefaultTimeout,
+		retry:   DefaultRetryPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.pin != "" {
+		cfg, err := pinTLS(c.tlsConfig, c.pin)
+		if err != nil {
+			return nil, err
+		}
+
+		c.tlsConfig = cfg
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{
+			Timeout: c.timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: c.tlsConfig,
+			},
+		}
+	}
+
+	s := service{client: c}
+
+	c.Access = (*AccessService)(&s)
+	c.Cluster = (*ClusterService)(&s)
+	c.LXC = &GuestService{client: c, kind: "lxc"}
+	c.Nodes = (*NodesService)(&s)
+	c.Qemu = &GuestService{client: c, kind: "qemu"}
+	c.Storage = (*StorageService)(&s)
+	c.Tasks = (*TasksService)(&s)
+
+	return c, nil
+}
+
+// BaseURL returns the server address the client was created with.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Delete sends a DELETE request and decodes the response data into dest,
+// which may be nil.
+func (c *Client) Delete(ctx context.Context, path string, dest any) error {
+	return c.Do(ctx, http.MethodDelete, path, nil, dest)
+}
+
+// Do sends a request to path (relative to /api2/json) and decodes the "data"
+// member of the response into dest, which may be nil. Transient failures are
+// retried according to the client's RetryPolicy.
+func (c *Client) Do(ctx context.Context, method, path string, body any, dest any) error {
+	var data []byte
+
+	if body != nil {
+		var err error
+
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+	}
+
+	retryable := c.retry.retryable(ctx, method)
+
+	for attempt := 1; ; attempt++ {
+		resp, respBytes, err := c.send(ctx, method, path, data)
+
+		var cause error
+
+		switch {
+		case err != nil && transientError(err):
+			cause = err
+		case err == nil && transientStatus(resp.StatusCode):
+			cause = newError(resp, respBytes, method, path)
+		}
+
+		if cause != nil && retryable && attempt < c.retry.MaxAttempts {
+			wait := c.retry.backoff(attempt, resp)
+
+			if c.retry.OnRetry != nil {
+				c.retry.OnRetry(method, path, attempt, wait, cause)
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return newError(resp, respBytes, method, path)
+		}
+
+		return decode(respBytes, dest)
+	}
+}
+
+// Get sends a GET request and decodes the response data into dest.
+func (c *Client) Get(ctx context.Context, path string, dest any) error {
+	return c.Do(ctx, http.MethodGet, path, nil, dest)
+}
+
+// Post sends a POST request and decodes the response data into dest, which
+// may be nil.
+func (c *Client) Post(ctx context.Context, path string, body any, dest any) error {
+	return c.Do(ctx, http.MethodPost, path, body, dest)
+}
+
+// Put sends a PUT request and decodes the response data into dest, which may
+// be nil.
+func (c *Client) Put(ctx context.Context, path string, body any, dest any) error {
+	return c.Do(ctx, http.MethodPut, path, body, dest)
+}
+
+// Version returns the version of the Proxmox VE API server.
+func (c *Client) Version(ctx context.Context) (*NodeVersion, error) {
+	var v NodeVersion
+
+	if err := c.Get(ctx, "/version", &v); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+func (c *Client) send(ctx context.Context, method, path string, data []byte) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+
+	if data != nil {
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/api2/json%s", c.baseURL, path), reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, respBytes, nil
+}
+
+func decode(respBytes []byte, dest any) error {
+	if dest == nil {
+		return nil
+	}
+
+	envelope := struct {
+		Data any `json:"data"`
+	}{Data: dest}
+
+	if err := json.Unmarshal(respBytes, &envelope); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) withoutAuth() *Client {
+	clone := *c
+	clone.auth = nil
+
+	return &clone
+}
+
+// deleteTask and postTask call endpoints that start a worker and answer with
+// its UPID.
+func (c *Client) deleteTask(ctx context.Context, path string) (string, error) {
+	var upid String
+
+	if err := c.Delete(ctx, path, &upid); err != nil {
+		return "", err
+	}
+
+	return string(upid), nil
+}
+
+func (c *Client) postTask(ctx context.Context, path string, body any) (string, error) {
+	var upid String
+
+	if err := c.Post(ctx, path, body, &upid); err != nil {
+		return "", err
+	}
+
+	return string(upid), nil
+}
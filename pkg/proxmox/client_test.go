@@ -0,0 +1,356 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// noRetry keeps failing requests to a single attempt.
+var noRetry = WithRetryPolicy(RetryPolicy{})
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, append([]Option{noRetry}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestNewRequiresBaseURL(t *testing.T) {
+	if _, err := New(""); err == nil {
+		t.Error("New(\"\") succeeded, want an error")
+	}
+}
+
+func TestClientDo(t *testing.T) {
+	var (
+		method string
+		path   string
+		auth   string
+		ctype  string
+		body   map[string]any
+	)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		auth, ctype = r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+
+		body = nil
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			_ = json.Unmarshal(data, &body)
+		}
+
+		_, _ = io.WriteString(w, `{"data":{"version":"8.2.4","release":"8.2"}}`)
+	}, WithAPIToken("root@pam!ci=secret"))
+
+	v, err := c.Version(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Version != "8.2.4" || v.Release != "8.2" {
+		t.Errorf("Version = %+v, want 8.2.4 / 8.2", v)
+	}
+
+	if method != http.MethodGet || path != "/api2/json/version" {
+		t.Errorf("request = %s %s, want GET /api2/json/version", method, path)
+	}
+
+	if auth != "PVEAPIToken=root@pam!ci=secret" {
+		t.Errorf("Authorization = %q", auth)
+	}
+
+	if err := c.Post(context.Background(), "/nodes/pve1/vzdump", Params{"vmid": "100"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if method != http.MethodPost || ctype != "application/json" || body["vmid"] != "100" {
+		t.Errorf("POST = %s %q %v, want POST application/json {vmid:100}", method, ctype, body)
+	}
+}
+
+func TestClientDoDecodeError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":`)
+	})
+
+	var v NodeVersion
+
+	if err := c.Get(context.Background(), "/version", &v); err == nil || !strings.Contains(err.Error(), "decode response") {
+		t.Errorf("Get = %v, want a decode error", err)
+	}
+}
+
+func TestErrorDecoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		message string
+		errors  map[string]string
+		text    string
+	}{
+		{
+			name:    "parameter errors",
+			status:  http.StatusBadRequest,
+			body:    `{"data":null,"errors":{"vmid":"invalid format","name":"missing"}}`,
+			message: "Bad Request",
+			errors:  map[string]string{"vmid": "invalid format", "name": "missing"},
+			text:    "API error 400: Bad Request; name: missing; vmid: invalid format",
+		},
+		{
+			name:    "message",
+			status:  http.StatusForbidden,
+			body:    `{"data":null,"message":"Permission check failed\n"}`,
+			message: "Permission check failed",
+			text:    "API error 403: Permission check failed",
+		},
+		{
+			name:    "errors as string",
+			status:  http.StatusInternalServerError,
+			body:    `{"data":null,"errors":"storage 'x' does not exist"}`,
+			message: "storage 'x' does not exist",
+			text:    "API error 500: storage 'x' does not exist",
+		},
+		{
+			name:    "plain text body",
+			status:  http.StatusBadGateway,
+			body:    "proxy error\n",
+			message: "proxy error",
+		},
+		{
+			name:    "empty body",
+			status:  http.StatusUnauthorized,
+			message: "Unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			})
+
+			err := c.Delete(context.Background(), "/access/users/bob@pve", nil)
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Delete = %v, want *Error", err)
+			}
+
+			if apiErr.StatusCode != tt.status || apiErr.Message != tt.message {
+				t.Errorf("Error = %d %q, want %d %q", apiErr.StatusCode, apiErr.Message, tt.status, tt.message)
+			}
+
+			if apiErr.Method != http.MethodDelete || apiErr.Path != "/access/users/bob@pve" {
+				t.Errorf("Error request = %s %s", apiErr.Method, apiErr.Path)
+			}
+
+			if len(apiErr.Errors) != len(tt.errors) {
+				t.Errorf("Errors = %v, want %v", apiErr.Errors, tt.errors)
+			}
+
+			for k, v := range tt.errors {
+				if apiErr.Errors[k] != v {
+					t.Errorf("Errors[%s] = %q, want %q", k, apiErr.Errors[k], v)
+				}
+			}
+
+			if tt.text != "" && apiErr.Error() != tt.text {
+				t.Errorf("Error() = %q, want %q", apiErr.Error(), tt.text)
+			}
+		})
+	}
+}
+
+func TestServicePaths(t *testing.T) {
+	var got string
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Method + " " + r.URL.EscapedPath()
+		_, _ = io.WriteString(w, `{"data":"UPID:pve1:1:2:3:x::root@pam:"}`)
+	})
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{
+			name: "guest snapshot name is escaped",
+			call: func() error { _, err := c.Qemu.DeleteSnapshot(ctx, "pve1", 100, "pre/upgrade"); return err },
+			want: "DELETE /api2/json/nodes/pve1/qemu/100/snapshot/pre%2Fupgrade",
+		},
+		{
+			name: "lxc rollback",
+			call: func() error { _, err := c.LXC.RollbackSnapshot(ctx, "pve2", 200, "daily"); return err },
+			want: "POST /api2/json/nodes/pve2/lxc/200/snapshot/daily/rollback",
+		},
+		{
+			name: "guest config",
+			call: func() error { return c.Qemu.UpdateConfig(ctx, "pve1", 101, Params{"memory": 2048}) },
+			want: "PUT /api2/json/nodes/pve1/qemu/101/config",
+		},
+		{
+			name: "backup job id is escaped",
+			call: func() error { _, err := c.Cluster.BackupJob(ctx, "backup-1 a/b"); return err },
+			want: "GET /api2/json/cluster/backup/backup-1%20a%2Fb",
+		},
+		{
+			name: "user id is escaped",
+			call: func() error { return c.Access.DeleteUser(ctx, "ci@pve!tok") },
+			want: "DELETE /api2/json/access/users/ci@pve%21tok",
+		},
+		{
+			name: "task upid is escaped",
+			call: func() error { return c.Tasks.Stop(ctx, "pve1", "UPID:pve1:1:2:3:x::root@pam:") },
+			want: "DELETE /api2/json/nodes/pve1/tasks/UPID:pve1:1:2:3:x::root@pam:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+
+			// Only the request path matters; decoding errors are ignored.
+			_ = tt.call()
+
+			if got != tt.want {
+				t.Errorf("request = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithFingerprint(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":{"version":"8.2.4"}}`)
+	}))
+	defer srv.Close()
+
+	pin := Fingerprint(srv.Certificate())
+
+	c, err := New(srv.URL, WithFingerprint(strings.ToLower(pin)), noRetry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Version(context.Background()); err != nil {
+		t.Errorf("Version with matching pin: %v", err)
+	}
+
+	other := strings.Repeat("AB:", 31) + "AB"
+
+	c, err = New(srv.URL, WithFingerprint(other), noRetry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Version(context.Background())
+
+	var pinErr *FingerprintError
+	if !errors.As(err, &pinErr) || pinErr.Got != pin {
+		t.Errorf("Version with wrong pin = %v, want *FingerprintError for %s", err, pin)
+	}
+
+	if _, err := New(srv.URL, WithFingerprint("not-a-fingerprint")); err == nil {
+		t.Error("New with an invalid fingerprint succeeded, want an error")
+	}
+}
+
+func TestWithRootCAs(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":{"version":"8.2.4"}}`)
+	}))
+	defer srv.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	c, err := New(srv.URL, WithRootCAs(pool), noRetry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Version(context.Background()); err != nil {
+		t.Errorf("Version with the server's CA: %v", err)
+	}
+
+	c, err = New(srv.URL, WithRootCAs(x509.NewCertPool()), noRetry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Version(context.Background()); err == nil {
+		t.Error("Version with an empty pool succeeded, want a certificate error")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}, WithTimeout(50*time.Millisecond))
+
+	start := time.Now()
+
+	if _, err := c.Version(context.Background()); err == nil {
+		t.Error("Version succeeded, want a timeout")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Version took %v, want it cut off after 50ms", elapsed)
+	}
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":{"version":"8.2.4"}}`)
+	}))
+	defer srv.Close()
+
+	// The injected client trusts the test certificate, so the empty root
+	// pool, which would otherwise fail, must be ignored.
+	c, err := New(srv.URL, WithRootCAs(x509.NewCertPool()), WithHTTPClient(srv.Client()), noRetry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Version(context.Background()); err != nil {
+		t.Errorf("Version through the injected client: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ClusterService covers cluster-wide endpoints under /cluster.
+type ClusterService service
+
+// BackupJob returns one scheduled backup job.
+func (s *ClusterService) BackupJob(ctx context.Context, id string) (*BackupJob, error) {
+	var job BackupJob
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/cluster/backup/%s", url.PathEscape(id)), &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// BackupJobs returns every scheduled backup job.
+func (s *ClusterService) BackupJobs(ctx context.Context) ([]BackupJob, error) {
+	var jobs []BackupJob
+
+	if err := s.client.Get(ctx, "/cluster/backup", &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// CreateBackupJob adds a scheduled backup job.
+func (s *ClusterService) CreateBackupJob(ctx context.Context, params Params) error {
+	return s.client.Post(ctx, "/cluster/backup", params, nil)
+}
+
+// DeleteBackupJob removes a scheduled backup job.
+func (s *ClusterService) DeleteBackupJob(ctx context.Context, id string) error {
+	return s.client.Delete(ctx, fmt.Sprintf("/cluster/backup/%s", url.PathEscape(id)), nil)
+}
+
+// Resources lists cluster resources. rtype filters by "vm", "storage",
+// "node", or "sdn"; an empty rtype returns everything.
+func (s *ClusterService) Resources(ctx context.Context, rtype string) ([]ClusterResource, error) {
+	path := "/cluster/resources"
+	if rtype != "" {
+		path += "?type=" + url.QueryEscape(rtype)
+	}
+
+	var resources []ClusterResource
+
+	if err := s.client.Get(ctx, path, &resources); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// Status returns the cluster entry (when clustered) and one entry per node.
+func (s *ClusterService) Status(ctx context.Context) ([]ClusterStatus, error) {
+	var status []ClusterStatus
+
+	if err := s.client.Get(ctx, "/cluster/status", &status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Tasks returns the recent tasks Proxmox keeps in the cluster-wide log.
+func (s *ClusterService) Tasks(ctx context.Context) ([]Task, error) {
+	var tasks []Task
+
+	if err := s.client.Get(ctx, "/cluster/tasks", &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// UpdateBackupJob changes the given properties of a backup job.
+func (s *ClusterService) UpdateBackupJob(ctx context.Context, id string, params Params) error {
+	return s.client.Put(Idempotent(ctx), fmt.Sprintf("/cluster/backup/%s", url.PathEscape(id)), params, nil)
+}
@@ -0,0 +1,31 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package proxmox is a client for the Proxmox VE REST API.
+//
+//	client, err := proxmox.New("https://pve.example.com:8006",
+//		proxmox.WithAPIToken("root@pam!automation=xxxxxxxx-xxxx"),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//
+//	vms, err := client.Qemu.List(ctx, "pve1")
+//
+// Endpoints are grouped into services on Client (Access, Cluster, LXC,
+// Nodes, Qemu, Storage, Tasks). Calls that start a worker task return its
+// UPID; pass it to Tasks.Wait to block until the task finishes.
+package proxmox
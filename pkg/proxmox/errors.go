@@ -0,0 +1,97 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Error is a non-2xx response from the API. Proxmox puts the message in the
+// HTTP reason phrase and per-parameter validation failures in the body's
+// "errors" object.
+type Error struct {
+	StatusCode int               `json:"status"`
+	Message    string            `json:"message"`
+	Errors     map[string]string `json:"errors,omitempty"`
+	Method     string            `json:"method,omitempty"`
+	Path       string            `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("API error %d", e.StatusCode)
+
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	for _, p := range e.Params() {
+		msg += fmt.Sprintf("; %s: %s", p, e.Errors[p])
+	}
+
+	return msg
+}
+
+// Params returns the names of the parameters that failed validation, sorted.
+func (e *Error) Params() []string {
+	params := make([]string, 0, len(e.Errors))
+
+	for p := range e.Errors {
+		params = append(params, p)
+	}
+
+	sort.Strings(params)
+
+	return params
+}
+
+func newError(resp *http.Response, body []byte, method, path string) *Error {
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Path:       path,
+	}
+
+	var parsed struct {
+		Message string          `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+
+	if json.Unmarshal(body, &parsed) == nil {
+		e.Message = strings.TrimSpace(parsed.Message)
+
+		// "errors" is usually an object, but older releases send a string.
+		if err := json.Unmarshal(parsed.Errors, &e.Errors); err != nil {
+			var text string
+			if json.Unmarshal(parsed.Errors, &text) == nil && e.Message == "" {
+				e.Message = strings.TrimSpace(text)
+			}
+		}
+	} else if text := strings.TrimSpace(string(body)); text != "" {
+		e.Message = text
+	}
+
+	if e.Message == "" {
+		// resp.Status is "400 Parameter verification failed."
+		e.Message = strings.TrimSpace(strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode)))
+	}
+
+	return e
+}
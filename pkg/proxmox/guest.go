@@ -0,0 +1,144 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GuestService manages KVM VMs (Client.Qemu) or LXC containers (Client.LXC).
+// Both kinds share the same endpoint layout under /nodes/{node}/{kind}.
+type GuestService struct {
+	client *Client
+	kind   string
+}
+
+// Action runs a power action ("start", "stop", "shutdown", "reboot",
+// "suspend", "resume") and returns the task UPID.
+func (s *GuestService) Action(ctx context.Context, node string, vmid int, action string) (string, error) {
+	return s.client.postTask(ctx, s.path(node, vmid, "status", action), nil)
+}
+
+// Clone copies the guest and returns the task UPID. params holds at least
+// "newid".
+func (s *GuestService) Clone(ctx context.Context, node string, vmid int, params Params) (string, error) {
+	return s.client.postTask(ctx, s.path(node, vmid, "clone"), params)
+}
+
+// Config returns the guest's current configuration.
+func (s *GuestService) Config(ctx context.Context, node string, vmid int) (GuestConfig, error) {
+	var config GuestConfig
+
+	if err := s.client.Get(ctx, s.path(node, vmid, "config"), &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Create creates (or, with an "archive" param, restores) a guest on node and
+// returns the task UPID.
+func (s *GuestService) Create(ctx context.Context, node string, params Params) (string, error) {
+	return s.client.postTask(ctx, fmt.Sprintf("/nodes/%s/%s", node, s.kind), params)
+}
+
+// CreateSnapshot takes a snapshot and returns the task UPID. params holds
+// at least "snapname".
+func (s *GuestService) CreateSnapshot(ctx context.Context, node string, vmid int, params Params) (string, error) {
+	return s.client.postTask(ctx, s.path(node, vmid, "snapshot"), params)
+}
+
+// Delete destroys the guest and returns the task UPID.
+func (s *GuestService) Delete(ctx context.Context, node string, vmid int) (string, error) {
+	return s.client.deleteTask(ctx, s.path(node, vmid))
+}
+
+// DeleteSnapshot removes a snapshot and returns the task UPID.
+func (s *GuestService) DeleteSnapshot(ctx context.Context, node string, vmid int, name string) (string, error) {
+	return s.client.deleteTask(ctx, s.path(node, vmid, "snapshot", name))
+}
+
+// Kind returns "qemu" or "lxc".
+func (s *GuestService) Kind() string {
+	return s.kind
+}
+
+// List returns the guests of this kind on node.
+func (s *GuestService) List(ctx context.Context, node string) ([]GuestSummary, error) {
+	var guests []GuestSummary
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/nodes/%s/%s", node, s.kind), &guests); err != nil {
+		return nil, err
+	}
+
+	return guests, nil
+}
+
+// RollbackSnapshot reverts the guest to a snapshot and returns the task UPID.
+func (s *GuestService) RollbackSnapshot(ctx context.Context, node string, vmid int, name string) (string, error) {
+	return s.client.postTask(ctx, s.path(node, vmid, "snapshot", name, "rollback"), nil)
+}
+
+// SnapshotConfig returns the guest configuration saved with a snapshot.
+func (s *GuestService) SnapshotConfig(ctx context.Context, node string, vmid int, name string) (GuestConfig, error) {
+	var config GuestConfig
+
+	if err := s.client.Get(ctx, s.path(node, vmid, "snapshot", name, "config"), &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Snapshots lists the guest's snapshots, including the "current" pseudo
+// snapshot Proxmox always returns.
+func (s *GuestService) Snapshots(ctx context.Context, node string, vmid int) ([]Snapshot, error) {
+	var snapshots []Snapshot
+
+	if err := s.client.Get(ctx, s.path(node, vmid, "snapshot"), &snapshots); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// Status returns the live status of one guest.
+func (s *GuestService) Status(ctx context.Context, node string, vmid int) (*GuestSummary, error) {
+	var status GuestSummary
+
+	if err := s.client.Get(ctx, s.path(node, vmid, "status", "current"), &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// UpdateConfig changes the given configuration keys.
+func (s *GuestService) UpdateConfig(ctx context.Context, node string, vmid int, params Params) error {
+	return s.client.Put(Idempotent(ctx), s.path(node, vmid, "config"), params, nil)
+}
+
+func (s *GuestService) path(node string, vmid int, elem ...string) string {
+	p := fmt.Sprintf("/nodes/%s/%s/%d", node, s.kind, vmid)
+
+	for _, e := range elem {
+		p += "/" + url.PathEscape(e)
+	}
+
+	return p
+}
@@ -0,0 +1,308 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"sort"
+	"strings"
+)
+
+type BackupJob struct {
+	ID            string `json:"id"`
+	Type          string `json:"type,omitempty"`
+	VMID          String `json:"vmid,omitempty"`
+	All           Bool   `json:"all,omitempty"`
+	Node          string `json:"node,omitempty"`
+	Storage       string `json:"storage"`
+	Schedule      string `json:"schedule"`
+	Mode          string `json:"mode,omitempty"`
+	Compress      string `json:"compress,omitempty"`
+	Enabled       Bool   `json:"enabled"`
+	MailTo        string `json:"mailto,omitempty"`
+	MaxFiles      Int    `json:"maxfiles,omitempty"`
+	PruneBackups  string `json:"prune-backups,omitempty"`
+	NotesTemplate string `json:"notes-template,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+	NextRun       Int    `json:"next-run,omitempty"`
+}
+
+type ClusterResource struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Node      string `json:"node,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Name      string `json:"name,omitempty"`
+	VMID      Int    `json:"vmid,omitempty"`
+	Storage   string `json:"storage,omitempty"`
+	Pool      string `json:"pool,omitempty"`
+	Tags      List   `json:"tags,omitempty"`
+	Template  Bool   `json:"template,omitempty"`
+	Lock      string `json:"lock,omitempty"`
+	CPU       Float  `json:"cpu"`
+	MaxCPU    Float  `json:"maxcpu"`
+	Mem       Int    `json:"mem"`
+	MaxMem    Int    `json:"maxmem"`
+	Disk      Int    `json:"disk"`
+	MaxDisk   Int    `json:"maxdisk"`
+	DiskRead  Int    `json:"diskread,omitempty"`
+	DiskWrite Int    `json:"diskwrite,omitempty"`
+	NetIn     Int    `json:"netin,omitempty"`
+	NetOut    Int    `json:"netout,omitempty"`
+	Uptime    Int    `json:"uptime"`
+	Content   List   `json:"content,omitempty"`
+	Shared    Bool   `json:"shared,omitempty"`
+	HAState   string `json:"hastate,omitempty"`
+}
+
+// ClusterStatus is one entry of /cluster/status: either the cluster itself or
+// one of its member nodes, distinguished by Type.
+type ClusterStatus struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Nodes   Int    `json:"nodes,omitempty"`
+	Quorate Bool   `json:"quorate,omitempty"`
+	Version Int    `json:"version,omitempty"`
+	Online  Bool   `json:"online,omitempty"`
+	Local   Bool   `json:"local,omitempty"`
+	IP      string `json:"ip,omitempty"`
+	Level   string `json:"level,omitempty"`
+	NodeID  Int    `json:"nodeid,omitempty"`
+}
+
+type Group struct {
+	GroupID string `json:"groupid"`
+	Comment string `json:"comment,omitempty"`
+	Users   List   `json:"users,omitempty"`
+	Members List   `json:"members,omitempty"`
+}
+
+// GuestConfig is a VM or container configuration. The key set varies per
+// guest (net0, scsi1, mp0, ...), so values are kept as strings.
+type GuestConfig map[string]String
+
+// GuestSummary is a VM or container as returned by the list and
+// status/current endpoints.
+type GuestSummary struct {
+	VMID      Int    `json:"vmid"`
+	Name      string `json:"name"`
+	Node      string `json:"node,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Pool      string `json:"pool,omitempty"`
+	Status    string `json:"status"`
+	QMPStatus string `json:"qmpstatus,omitempty"`
+	Lock      string `json:"lock,omitempty"`
+	Tags      List   `json:"tags,omitempty"`
+	Template  Bool   `json:"template,omitempty"`
+	CPU       Float  `json:"cpu"`
+	CPUs      Float  `json:"cpus"`
+	Mem       Int    `json:"mem"`
+	MaxMem    Int    `json:"maxmem"`
+	Disk      Int    `json:"disk"`
+	MaxDisk   Int    `json:"maxdisk"`
+	DiskRead  Int    `json:"diskread"`
+	DiskWrite Int    `json:"diskwrite"`
+	NetIn     Int    `json:"netin"`
+	NetOut    Int    `json:"netout"`
+	Uptime    Int    `json:"uptime"`
+	PID       Int    `json:"pid,omitempty"`
+}
+
+type Node struct {
+	Node           string `json:"node"`
+	ID             string `json:"id,omitempty"`
+	Status         string `json:"status"`
+	Level          string `json:"level,omitempty"`
+	CPU            Float  `json:"cpu"`
+	MaxCPU         Float  `json:"maxcpu"`
+	Mem            Int    `json:"mem"`
+	MaxMem         Int    `json:"maxmem"`
+	Disk           Int    `json:"disk"`
+	MaxDisk        Int    `json:"maxdisk"`
+	Uptime         Int    `json:"uptime"`
+	SSLFingerprint string `json:"ssl_fingerprint,omitempty"`
+}
+
+type NodeStatus struct {
+	PVEVersion string  `json:"pveversion"`
+	KVersion   string  `json:"kversion"`
+	Uptime     Int     `json:"uptime"`
+	CPU        Float   `json:"cpu"`
+	Wait       Float   `json:"wait"`
+	LoadAvg    []Float `json:"loadavg"`
+	CPUInfo    struct {
+		Model   string `json:"model"`
+		Sockets Int    `json:"sockets"`
+		Cores   Int    `json:"cores"`
+		CPUs    Int    `json:"cpus"`
+		MHz     String `json:"mhz,omitempty"`
+	} `json:"cpuinfo"`
+	Memory NodeUsage `json:"memory"`
+	Swap   NodeUsage `json:"swap"`
+	RootFS NodeUsage `json:"rootfs"`
+
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// NodeTime is a node's clock as reported by /nodes/{node}/time.
+type NodeTime struct {
+	Time      Int    `json:"time"`
+	LocalTime Int    `json:"localtime"`
+	Timezone  string `json:"timezone"`
+}
+
+type NodeUsage struct {
+	Used  Int `json:"used"`
+	Free  Int `json:"free"`
+	Total Int `json:"total"`
+	Avail Int `json:"avail,omitempty"`
+}
+
+type NodeVersion struct {
+	Version string `json:"version"`
+	Release string `json:"release"`
+	RepoID  string `json:"repoid"`
+}
+
+// Permissions maps ACL paths to the privileges held there. A true value means
+// the privilege propagates to child paths.
+type Permissions map[string]map[string]Bool
+
+type Snapshot struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+	SnapTime    Int    `json:"snaptime,omitempty"`
+	VMState     Bool   `json:"vmstate"`
+	Running     Bool   `json:"running,omitempty"`
+}
+
+// StorageConfig is the cluster-wide definition of a storage from /storage.
+type StorageConfig struct {
+	Storage      string `json:"storage"`
+	Type         string `json:"type"`
+	Content      List   `json:"content,omitempty"`
+	Shared       Bool   `json:"shared"`
+	Disable      Bool   `json:"disable"`
+	Nodes        List   `json:"nodes,omitempty"`
+	Path         string `json:"path,omitempty"`
+	Server       string `json:"server,omitempty"`
+	Export       string `json:"export,omitempty"`
+	Pool         string `json:"pool,omitempty"`
+	Datastore    string `json:"datastore,omitempty"`
+	PruneBackups string `json:"prune-backups,omitempty"`
+	Digest       string `json:"digest,omitempty"`
+}
+
+type StorageContent struct {
+	VolID        string `json:"volid"`
+	Content      string `json:"content"`
+	Format       string `json:"format"`
+	Size         Int    `json:"size"`
+	Used         Int    `json:"used,omitempty"`
+	VMID         Int    `json:"vmid,omitempty"`
+	CTime        Int    `json:"ctime,omitempty"`
+	Notes        string `json:"notes,omitempty"`
+	Parent       string `json:"parent,omitempty"`
+	Protected    Bool   `json:"protected"`
+	Encrypted    String `json:"encrypted,omitempty"`
+	Verification any    `json:"verification,omitempty"`
+}
+
+// StorageStatus is a storage as seen by one node, including live usage.
+type StorageStatus struct {
+	Storage      string `json:"storage"`
+	Type         string `json:"type"`
+	Content      List   `json:"content,omitempty"`
+	Active       Bool   `json:"active"`
+	Enabled      Bool   `json:"enabled"`
+	Shared       Bool   `json:"shared"`
+	Used         Int    `json:"used"`
+	Avail        Int    `json:"avail"`
+	Total        Int    `json:"total"`
+	UsedFraction Float  `json:"used_fraction,omitempty"`
+}
+
+type Task struct {
+	UPID      string `json:"upid"`
+	Node      string `json:"node"`
+	PID       Int    `json:"pid"`
+	PStart    Int    `json:"pstart"`
+	StartTime Int    `json:"starttime"`
+	EndTime   Int    `json:"endtime,omitempty"`
+	Type      string `json:"type"`
+	ID        String `json:"id"`
+	User      string `json:"user"`
+	Status    string `json:"status,omitempty"`
+}
+
+type TaskLogLine struct {
+	N int    `json:"n"`
+	T string `json:"t"`
+}
+
+type TaskStatus struct {
+	UPID       string `json:"upid"`
+	Status     string `json:"status"`
+	ExitStatus string `json:"exitstatus"`
+	Type       string `json:"type"`
+	ID         String `json:"id"`
+	User       string `json:"user"`
+	Node       string `json:"node"`
+	StartTime  Int    `json:"starttime"`
+	PID        Int    `json:"pid"`
+}
+
+type User struct {
+	UserID    string `json:"userid"`
+	Enable    Bool   `json:"enable"`
+	Expire    Int    `json:"expire"`
+	FirstName string `json:"firstname,omitempty"`
+	LastName  string `json:"lastname,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	Groups    List   `json:"groups"`
+	Keys      string `json:"keys,omitempty"`
+	RealmType string `json:"realm-type,omitempty"`
+}
+
+// Keys returns the configuration keys in sorted order.
+func (c GuestConfig) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Running reports whether the task has not finished yet.
+func (s *TaskStatus) Running() bool {
+	return s.Status == "running"
+}
+
+// Succeeded reports whether a finished task ended without errors. Proxmox
+// marks tasks that only logged warnings as "WARNINGS: n".
+func (s *TaskStatus) Succeeded() bool {
+	return s.ExitStatus == "OK" || strings.HasPrefix(string(s.ExitStatus), "WARNINGS")
+}
+
+// Running reports whether the task has not finished yet.
+func (t *Task) Running() bool {
+	return t.EndTime == 0
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"fmt"
+)
+
+// NodesService covers per-node endpoints under /nodes.
+type NodesService service
+
+// List returns every node in the cluster.
+func (s *NodesService) List(ctx context.Context) ([]Node, error) {
+	var nodes []Node
+
+	if err := s.client.Get(ctx, "/nodes", &nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// Status returns the node's load, memory, and uptime.
+func (s *NodesService) Status(ctx context.Context, node string) (*NodeStatus, error) {
+	var status NodeStatus
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/nodes/%s/status", node), &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// Time returns the node's clock, e.g. to measure skew against the local one.
+func (s *NodesService) Time(ctx context.Context, node string) (*NodeTime, error) {
+	var t NodeTime
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/nodes/%s/time", node), &t); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// Version returns the Proxmox VE version running on the node.
+func (s *NodesService) Version(ctx context.Context, node string) (*NodeVersion, error) {
+	var version NodeVersion
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/nodes/%s/version", node), &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// Vzdump starts a backup on node and returns the task UPID.
+func (s *NodesService) Vzdump(ctx context.Context, node string, params Params) (string, error) {
+	return s.client.postTask(ctx, fmt.Sprintf("/nodes/%s/vzdump", node), params)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithAPIToken authenticates with an API token of the form
+// "USER@REALM!TOKENID=SECRET".
+func WithAPIToken(token string) Option {
+	return WithAuthenticator(APIToken(token))
+}
+
+// WithAuthenticator sets how requests are authenticated.
+func WithAuthenticator(a Authenticator) Option {
+	return func(c *Client) {
+		c.auth = a
+	}
+}
+
+// WithFingerprint pins the server certificate to a SHA-256 fingerprint such
+// as "AB:CD:...". The certificate chain is not verified, so a self-signed
+// certificate is accepted as long as it matches; any other certificate fails
+// with a *FingerprintError.
+func WithFingerprint(fingerprint string) Option {
+	return func(c *Client) {
+		c.pin = fingerprint
+	}
+}
+
+// WithHTTPClient sends requests through hc. The TLS and timeout options are
+// ignored when an HTTP client is supplied; configure hc instead.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithInsecureSkipVerify disables certificate verification, which is common
+// for homelab installs with the default self-signed certificate.
+func WithInsecureSkipVerify(skip bool) Option {
+	return func(c *Client) {
+		if c.tlsConfig == nil {
+			c.tlsConfig = &tls.Config{} //nolint:gosec
+		}
+
+		c.tlsConfig.InsecureSkipVerify = skip //nolint:gosec
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy. Use RetryPolicy{} to turn
+// retries off.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// WithRootCAs verifies the server certificate against pool instead of the
+// system roots, e.g. for a cluster signed by a private CA.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) {
+		if c.tlsConfig == nil {
+			c.tlsConfig = &tls.Config{}
+		}
+
+		c.tlsConfig.RootCAs = pool
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to reach the server.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithTimeout bounds each request. Zero means no limit.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries requests that fail with a transient error: a dropped
+// connection or one of the status codes Proxmox returns while a node is busy
+// or unreachable (500, 502, 503, 504, 595, 596, and 429). GET requests are
+// retried automatically; other methods only when their context is marked
+// with Idempotent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the wait before the first retry. It doubles on every
+	// retry, with jitter, up to MaxDelay. MaxDelay also caps a server's
+	// Retry-After, so a request to wait 30s waits at most MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// OnRetry, when set, is called before each retry.
+	OnRetry func(method, path string, attempt int, wait time.Duration, err error)
+}
+
+type idempotentKey struct{}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy says otherwise.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// Idempotent marks requests made with the returned context as safe to
+// retry even though they are not GETs.
+func Idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// backoff returns the wait before retry number attempt (1-based), preferring
+// the server's Retry-After, capped at MaxDelay, when it sent one.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return min(wait, p.MaxDelay)
+	}
+
+	wait := p.BaseDelay << (attempt - 1)
+	if wait <= 0 || wait > p.MaxDelay {
+		wait = p.MaxDelay
+	}
+
+	// Equal jitter: somewhere between half and all of the computed delay.
+	half := wait / 2
+
+	return half + rand.N(half+1)
+}
+
+func (p RetryPolicy) retryable(ctx context.Context, method string) bool {
+	if p.MaxAttempts < 2 {
+		return false
+	}
+
+	if method == http.MethodGet {
+		return true
+	}
+
+	idempotent, _ := ctx.Value(idempotentKey{}).(bool)
+
+	return idempotent
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		return max(time.Until(when), 0), true
+	}
+
+	return 0, false
+}
+
+func transientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// An untrusted certificate will not become trusted on the next attempt.
+	var certErr *tls.CertificateVerificationError
+	var pinErr *FingerprintError
+	if errors.As(err, &certErr) || errors.As(err, &pinErr) {
+		return false
+	}
+
+	// A request that hit the client timeout already used its full budget.
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return false
+	}
+
+	return true
+}
+
+func transientStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+		595, // pveproxy: no route to host / connection refused to the node
+		596: // pveproxy: connection to the node timed out
+		return true
+	}
+
+	return false
+}
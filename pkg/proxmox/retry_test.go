@@ -0,0 +1,239 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry retries quickly enough for tests.
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+// flaky answers with status until it has failed failures times, then with
+// an empty success.
+func flaky(status, failures int, hits *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if int(hits.Add(1)) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"data":null}`)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		failures int
+		method   string
+		ctx      context.Context
+		wantHits int32
+		wantErr  bool
+	}{
+		{"get recovers", 596, 2, http.MethodGet, context.Background(), 3, false},
+		{"get gives up", http.StatusServiceUnavailable, 5, http.MethodGet, context.Background(), 3, true},
+		{"post is not retried", http.StatusServiceUnavailable, 1, http.MethodPost, context.Background(), 1, true},
+		{"idempotent put", http.StatusBadGateway, 2, http.MethodPut, Idempotent(context.Background()), 3, false},
+		{"plain put is not retried", http.StatusBadGateway, 1, http.MethodPut, context.Background(), 1, true},
+		{"client error is not retried", http.StatusBadRequest, 1, http.MethodGet, context.Background(), 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+
+			c := newTestClient(t, flaky(tt.status, tt.failures, &hits), WithRetryPolicy(fastRetry))
+
+			err := c.Do(tt.ctx, tt.method, "/version", nil, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Do = %v, want error: %v", err, tt.wantErr)
+			}
+
+			if got := hits.Load(); got != tt.wantHits {
+				t.Errorf("attempts = %d, want %d", got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestRetryCallsOnRetry(t *testing.T) {
+	var (
+		hits    atomic.Int32
+		retries []int
+	)
+
+	p := fastRetry
+	p.OnRetry = func(method, path string, attempt int, wait time.Duration, err error) {
+		var apiErr *Error
+		if method != http.MethodGet || path != "/version" || !errors.As(err, &apiErr) || apiErr.StatusCode != 595 {
+			t.Errorf("OnRetry(%s, %s, %d, %v, %v)", method, path, attempt, wait, err)
+		}
+
+		retries = append(retries, attempt)
+	}
+
+	c := newTestClient(t, flaky(595, 2, &hits), WithRetryPolicy(p))
+
+	if err := c.Get(context.Background(), "/version", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(retries) != 2 || retries[0] != 1 || retries[1] != 2 {
+		t.Errorf("OnRetry attempts = %v, want [1 2]", retries)
+	}
+}
+
+func TestRetryCertificateErrors(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":null}`)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"untrusted certificate", WithRootCAs(x509.NewCertPool())},
+		{"fingerprint mismatch", WithFingerprint(strings.Repeat("00:", 31) + "00")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retries := 0
+
+			p := fastRetry
+			p.OnRetry = func(string, string, int, time.Duration, error) { retries++ }
+
+			c, err := New(srv.URL, tt.opt, WithRetryPolicy(p))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.Get(context.Background(), "/version", nil); err == nil {
+				t.Fatal("Get succeeded, want a certificate error")
+			}
+
+			if retries != 0 {
+				t.Errorf("retried %d times, want none", retries)
+			}
+		})
+	}
+}
+
+func TestRetryCancelDuringWait(t *testing.T) {
+	var hits atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Get(ctx, "/version", nil)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get = %v, want context.DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get returned after %v, want it to stop waiting on cancellation", elapsed)
+	}
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		header   string
+		min, max time.Duration
+	}{
+		{"first retry", 1, "", 50 * time.Millisecond, 100 * time.Millisecond},
+		{"doubles", 3, "", 200 * time.Millisecond, 400 * time.Millisecond},
+		{"capped", 10, "", 500 * time.Millisecond, time.Second},
+		{"retry-after seconds", 1, "0", 0, 0},
+		{"retry-after clamped to MaxDelay", 1, "30", time.Second, time.Second},
+		{"retry-after date clamped", 1, time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), time.Second, time.Second},
+		{"retry-after date in the past", 1, time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, 0},
+		{"retry-after garbage ignored", 1, "soon", 50 * time.Millisecond, 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+
+			for range 20 {
+				if got := p.backoff(tt.attempt, resp); got < tt.min || got > tt.max {
+					t.Fatalf("backoff(%d) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", time.Now().Add(3*time.Second).UTC().Format(http.TimeFormat))
+
+	// HTTP dates have one-second resolution.
+	wait, ok := retryAfter(resp)
+	if !ok || wait < time.Second || wait > 3*time.Second {
+		t.Errorf("retryAfter = %v, %v; want about 3s", wait, ok)
+	}
+}
+
+func TestTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"connection reset", errors.New("read: connection reset by peer"), true},
+		{"canceled", context.Canceled, false},
+		{"deadline", context.DeadlineExceeded, false},
+		{"fingerprint", &FingerprintError{Want: "a", Got: "b"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := transientError(tt.err); got != tt.want {
+			t.Errorf("transientError(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
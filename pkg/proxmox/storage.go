@@ -0,0 +1,145 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// StorageService covers storage definitions (/storage) and their per-node
+// views (/nodes/{node}/storage).
+type StorageService service
+
+// ContentOptions filters Content. Zero values are not sent.
+type ContentOptions struct {
+	Content string // e.g. "backup", "iso", "images"
+	VMID    string
+}
+
+// StorageListOptions filters NodeList. Zero values are not sent.
+type StorageListOptions struct {
+	Content string
+	Enabled bool
+}
+
+// Content lists the volumes on storage as seen from node.
+func (s *StorageService) Content(ctx context.Context, node, storage string, opts ContentOptions) ([]StorageContent, error) {
+	q := url.Values{}
+
+	if opts.Content != "" {
+		q.Set("content", opts.Content)
+	}
+
+	if opts.VMID != "" {
+		q.Set("vmid", opts.VMID)
+	}
+
+	var content []StorageContent
+
+	if err := s.client.Get(ctx, withQuery(s.nodePath(node, storage, "content"), q), &content); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// DeleteVolume removes a volume and returns the task UPID. volume is the
+// part of the volume ID after "storage:".
+func (s *StorageService) DeleteVolume(ctx context.Context, node, storage, volume string) (string, error) {
+	return s.client.deleteTask(ctx, s.nodePath(node, storage, "content", volume))
+}
+
+// Get returns the cluster-wide definition of one storage.
+func (s *StorageService) Get(ctx context.Context, storage string) (*StorageConfig, error) {
+	var config StorageConfig
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/storage/%s", url.PathEscape(storage)), &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// List returns every storage defined in the cluster.
+func (s *StorageService) List(ctx context.Context) ([]StorageConfig, error) {
+	var configs []StorageConfig
+
+	if err := s.client.Get(ctx, "/storage", &configs); err != nil {
+		return nil, err
+	}
+
+	return configs, nil
+}
+
+// NodeList returns the storages available on node with live usage.
+func (s *StorageService) NodeList(ctx context.Context, node string, opts StorageListOptions) ([]StorageStatus, error) {
+	q := url.Values{}
+
+	if opts.Content != "" {
+		q.Set("content", opts.Content)
+	}
+
+	if opts.Enabled {
+		q.Set("enabled", "1")
+	}
+
+	var status []StorageStatus
+
+	if err := s.client.Get(ctx, withQuery(fmt.Sprintf("/nodes/%s/storage", node), q), &status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Status returns live usage of one storage on node.
+func (s *StorageService) Status(ctx context.Context, node, storage string) (*StorageStatus, error) {
+	var status StorageStatus
+
+	if err := s.client.Get(ctx, s.nodePath(node, storage, "status"), &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// Volume returns the details of one volume.
+func (s *StorageService) Volume(ctx context.Context, node, storage, volume string) (*StorageContent, error) {
+	var content StorageContent
+
+	if err := s.client.Get(ctx, s.nodePath(node, storage, "content", volume), &content); err != nil {
+		return nil, err
+	}
+
+	return &content, nil
+}
+
+// nodePath leaves elem unescaped: volume names such as
+// "backup/vzdump-qemu-100.vma.zst" contain slashes Proxmox expects as-is.
+func (s *StorageService) nodePath(node, storage string, elem ...string) string {
+	return strings.Join(append([]string{fmt.Sprintf("/nodes/%s/storage/%s", node, url.PathEscape(storage))}, elem...), "/")
+}
+
+func withQuery(path string, q url.Values) string {
+	if len(q) == 0 {
+		return path
+	}
+
+	return path + "?" + q.Encode()
+}
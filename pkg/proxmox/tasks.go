@@ -0,0 +1,190 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// TaskLogPageSize is how many log lines Log requests at a time.
+	TaskLogPageSize = 500
+
+	// TaskPollInterval is how often Wait checks a running task.
+	TaskPollInterval = 2 * time.Second
+)
+
+// TasksService covers worker tasks under /nodes/{node}/tasks.
+type TasksService service
+
+// TaskListOptions filters List. Zero values are not sent.
+type TaskListOptions struct {
+	Source     string // "active", "archive", or "all"
+	Limit      int
+	Errors     bool
+	TypeFilter string
+	VMID       string
+	UserFilter string
+	Since      time.Time
+	Until      time.Time
+}
+
+// FullLog returns every log line the task has written so far.
+func (s *TasksService) FullLog(ctx context.Context, node, upid string) ([]TaskLogLine, error) {
+	lines := []TaskLogLine{}
+
+	for {
+		page, err := s.Log(ctx, node, upid, len(lines))
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, page...)
+
+		if len(page) < TaskLogPageSize {
+			return lines, nil
+		}
+	}
+}
+
+// List returns the task history of node.
+func (s *TasksService) List(ctx context.Context, node string, opts TaskListOptions) ([]Task, error) {
+	q := url.Values{}
+
+	if opts.Source != "" {
+		q.Set("source", opts.Source)
+	}
+
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	if opts.Errors {
+		q.Set("errors", "1")
+	}
+
+	if opts.TypeFilter != "" {
+		q.Set("typefilter", opts.TypeFilter)
+	}
+
+	if opts.VMID != "" {
+		q.Set("vmid", opts.VMID)
+	}
+
+	if opts.UserFilter != "" {
+		q.Set("userfilter", opts.UserFilter)
+	}
+
+	if !opts.Since.IsZero() {
+		q.Set("since", strconv.FormatInt(opts.Since.Unix(), 10))
+	}
+
+	if !opts.Until.IsZero() {
+		q.Set("until", strconv.FormatInt(opts.Until.Unix(), 10))
+	}
+
+	var tasks []Task
+
+	if err := s.client.Get(ctx, withQuery(fmt.Sprintf("/nodes/%s/tasks", node), q), &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Log returns up to TaskLogPageSize log lines starting at line start.
+func (s *TasksService) Log(ctx context.Context, node, upid string, start int) ([]TaskLogLine, error) {
+	var lines []TaskLogLine
+
+	path := fmt.Sprintf("/nodes/%s/tasks/%s/log?start=%d&limit=%d", node, url.PathEscape(upid), start, TaskLogPageSize)
+
+	if err := s.client.Get(ctx, path, &lines); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// Status returns the state and, once stopped, the exit status of a task.
+func (s *TasksService) Status(ctx context.Context, node, upid string) (*TaskStatus, error) {
+	var status TaskStatus
+
+	if err := s.client.Get(ctx, fmt.Sprintf("/nodes/%s/tasks/%s/status", node, url.PathEscape(upid)), &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// Stop asks Proxmox to abort a running task.
+func (s *TasksService) Stop(ctx context.Context, node, upid string) error {
+	return s.client.Delete(ctx, fmt.Sprintf("/nodes/%s/tasks/%s", node, url.PathEscape(upid)), nil)
+}
+
+// Wait polls the task until it stops or ctx is done. New log lines are
+// written to log when it is not nil.
+func (s *TasksService) Wait(ctx context.Context, node, upid string, log io.Writer) (*TaskStatus, error) {
+	var next int
+
+	for {
+		status, err := s.Status(ctx, node, upid)
+		if err != nil {
+			return nil, err
+		}
+
+		if log != nil {
+			if next, err = s.WriteLog(ctx, node, upid, next, log); err != nil {
+				return nil, err
+			}
+		}
+
+		if !status.Running() {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(TaskPollInterval):
+		}
+	}
+}
+
+// WriteLog writes every log line from start onwards to w and returns the
+// offset of the next unread line.
+func (s *TasksService) WriteLog(ctx context.Context, node, upid string, start int, w io.Writer) (int, error) {
+	for {
+		lines, err := s.Log(ctx, node, upid, start)
+		if err != nil {
+			return start, err
+		}
+
+		for _, l := range lines {
+			_, _ = fmt.Fprintln(w, l.T)
+		}
+
+		start += len(lines)
+
+		if len(lines) < TaskLogPageSize {
+			return start, nil
+		}
+	}
+}
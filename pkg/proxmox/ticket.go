@@ -0,0 +1,160 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TicketLifetime is how long Proxmox accepts a ticket after it was issued.
+const TicketLifetime = 2 * time.Hour
+
+// Ticket is a session from /access/ticket. It authenticates with the
+// PVEAuthCookie cookie and, on writes, the CSRFPreventionToken header.
+type Ticket struct {
+	Username  string    `json:"username"`
+	Ticket    string    `json:"ticket"`
+	CSRFToken string    `json:"CSRFPreventionToken"`
+	NeedTFA   Bool      `json:"NeedTFA,omitempty"`
+	Issued    time.Time `json:"issued"`
+}
+
+// TFAChallenge lists the second factors a partial ticket can be completed
+// with.
+type TFAChallenge struct {
+	Methods []string
+}
+
+func (t *Ticket) Authenticate(req *http.Request) error {
+	if t.NeedTFA {
+		return fmt.Errorf("login incomplete: a second factor is required")
+	}
+
+	req.AddCookie(&http.Cookie{Name: "PVEAuthCookie", Value: t.Ticket})
+
+	if req.Method != http.MethodGet {
+		req.Header.Set("CSRFPreventionToken", t.CSRFToken)
+	}
+
+	return nil
+}
+
+// Challenge decodes the second factors offered in a partial ticket, whose
+// form is "PVE:!tfa!<url-encoded JSON>:...".
+func (t *Ticket) Challenge() (*TFAChallenge, error) {
+	_, rest, ok := strings.Cut(t.Ticket, "!tfa!")
+	if !ok {
+		return nil, fmt.Errorf("ticket carries no TFA challenge")
+	}
+
+	raw, _, _ := strings.Cut(rest, ":")
+
+	text, err := url.QueryUnescape(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decode TFA challenge: %w", err)
+	}
+
+	var offered map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(text), &offered); err != nil {
+		return nil, fmt.Errorf("decode TFA challenge: %w", err)
+	}
+
+	c := &TFAChallenge{}
+
+	for method, value := range offered {
+		switch string(value) {
+		case "null", "false", "[]", "{}", `""`, "0":
+			continue
+		}
+
+		c.Methods = append(c.Methods, method)
+	}
+
+	sort.Strings(c.Methods)
+
+	return c, nil
+}
+
+// Expires returns when Proxmox stops accepting the ticket.
+func (t *Ticket) Expires() time.Time {
+	return t.Issued.Add(TicketLifetime)
+}
+
+// Offers reports whether the challenge accepts the given method, e.g.
+// "totp" or "recovery".
+func (c *TFAChallenge) Offers(method string) bool {
+	for _, m := range c.Methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CompleteTFA finishes a login that returned a ticket with NeedTFA set.
+// response is the second factor prefixed with its type, e.g. "totp:123456"
+// or "recovery:abcd-1234".
+func (s *AccessService) CompleteTFA(ctx context.Context, partial *Ticket, response string) (*Ticket, error) {
+	return s.createTicket(ctx, Params{
+		"username":      partial.Username,
+		"tfa-challenge": partial.Ticket,
+		"password":      response,
+		"new-format":    1,
+	})
+}
+
+// Login exchanges a username ("user@realm") and password for a ticket. If
+// the account has a second factor the ticket has NeedTFA set and must be
+// completed with CompleteTFA before use.
+//
+// new-format asks PVE 7 for the "!tfa!" challenge that PVE 8 always sends,
+// rather than the legacy TFA response Challenge cannot parse.
+func (s *AccessService) Login(ctx context.Context, username, password string) (*Ticket, error) {
+	return s.createTicket(ctx, Params{
+		"username":   username,
+		"password":   password,
+		"new-format": 1,
+	})
+}
+
+// RenewTicket trades a still-valid ticket for a fresh one.
+func (s *AccessService) RenewTicket(ctx context.Context, t *Ticket) (*Ticket, error) {
+	return s.createTicket(ctx, Params{
+		"username": t.Username,
+		"password": t.Ticket,
+	})
+}
+
+func (s *AccessService) createTicket(ctx context.Context, params Params) (*Ticket, error) {
+	var t Ticket
+
+	// The ticket endpoint takes no credentials, so bypass the client's own.
+	if err := s.client.withoutAuth().Post(ctx, "/access/ticket", params, &t); err != nil {
+		return nil, err
+	}
+
+	t.Issued = time.Now()
+
+	return &t, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestLoginRequestsNewTFAFormat(t *testing.T) {
+	var (
+		bodies []map[string]any
+		auth   []string
+	)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+
+		bodies = append(bodies, body)
+		auth = append(auth, r.Header.Get("Authorization"))
+
+		if body["tfa-challenge"] == nil {
+			_, _ = io.WriteString(w, `{"data":{"username":"alice@pve","ticket":"PVE:!tfa!%7B%22totp%22%3Atrue%7D:x","NeedTFA":1}}`)
+			return
+		}
+
+		_, _ = io.WriteString(w, `{"data":{"username":"alice@pve","ticket":"PVE:alice@pve:full","CSRFPreventionToken":"csrf"}}`)
+	}, WithAPIToken("root@pam!ci=secret"))
+
+	ctx := context.Background()
+
+	partial, err := c.Access.Login(ctx, "alice@pve", "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	challenge, err := partial.Challenge()
+	if err != nil || !bool(partial.NeedTFA) || !challenge.Offers("totp") {
+		t.Fatalf("Login = %+v, challenge %v, %v; want a TOTP challenge", partial, challenge, err)
+	}
+
+	full, err := c.Access.CompleteTFA(ctx, partial, "totp:123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bool(full.NeedTFA) || full.CSRFToken != "csrf" {
+		t.Errorf("CompleteTFA = %+v, want a full ticket", full)
+	}
+
+	for i, body := range bodies {
+		if body["new-format"] != float64(1) {
+			t.Errorf("request %d: new-format = %v, want 1", i+1, body["new-format"])
+		}
+
+		if auth[i] != "" {
+			t.Errorf("request %d sent Authorization %q, want none", i+1, auth[i])
+		}
+	}
+
+	if bodies[1]["tfa-challenge"] != partial.Ticket || bodies[1]["password"] != "totp:123456" {
+		t.Errorf("CompleteTFA body = %v", bodies[1])
+	}
+}
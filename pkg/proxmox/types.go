@@ -0,0 +1,170 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Proxmox is loose about JSON types: integers arrive as strings, booleans as
+// 0/1, and lists as comma-separated strings depending on the endpoint and PVE
+// version. The types below decode any of those forms and always encode in
+// their natural JSON form.
+
+type Bool bool
+
+type Float float64
+
+type Int int64
+
+type List []string
+
+type String string
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	raw, ok := unquote(data)
+	if !ok {
+		return nil
+	}
+
+	switch strings.ToLower(raw) {
+	case "1", "true", "yes", "on":
+		*b = true
+	case "", "0", "false", "no", "off":
+		*b = false
+	default:
+		return fmt.Errorf("cannot decode %s as boolean", data)
+	}
+
+	return nil
+}
+
+func (f *Float) UnmarshalJSON(data []byte) error {
+	raw, ok := unquote(data)
+	if !ok || raw == "" {
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s as number", data)
+	}
+
+	*f = Float(v)
+
+	return nil
+}
+
+func (i *Int) UnmarshalJSON(data []byte) error {
+	raw, ok := unquote(data)
+	if !ok || raw == "" {
+		return nil
+	}
+
+	if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		*i = Int(v)
+		return nil
+	}
+
+	// Large counters are sometimes serialized as floats.
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s as integer", data)
+	}
+
+	*i = Int(v)
+
+	return nil
+}
+
+func (l *List) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var items []String
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+
+		out := make(List, 0, len(items))
+		for _, item := range items {
+			out = append(out, string(item))
+		}
+
+		*l = out
+
+		return nil
+	}
+
+	raw, ok := unquote(data)
+	if !ok || raw == "" {
+		*l = nil
+		return nil
+	}
+
+	out := List{}
+
+	for _, item := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+
+	*l = out
+
+	return nil
+}
+
+func (s *String) UnmarshalJSON(data []byte) error {
+	var text string
+
+	if err := json.Unmarshal(data, &text); err == nil {
+		*s = String(text)
+		return nil
+	}
+
+	if raw, ok := unquote(data); ok {
+		*s = String(raw)
+	}
+
+	return nil
+}
+
+func (l List) String() string {
+	return strings.Join(l, ",")
+}
+
+// unquote returns the scalar text of a JSON value, or false for null.
+func unquote(data []byte) (string, bool) {
+	data = bytes.TrimSpace(data)
+
+	if bytes.Equal(data, []byte("null")) {
+		return "", false
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", false
+		}
+
+		return strings.TrimSpace(s), true
+	}
+
+	return string(data), true
+}
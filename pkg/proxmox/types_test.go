@@ -0,0 +1,203 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestBoolUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Bool
+	}{
+		{`true`, true},
+		{`false`, false},
+		{`1`, true},
+		{`0`, false},
+		{`"1"`, true},
+		{`"0"`, false},
+		{`"true"`, true},
+		{`"Yes"`, true},
+		{`"on"`, true},
+		{`"off"`, false},
+		{`""`, false},
+		{`null`, false},
+	}
+
+	for _, tt := range tests {
+		var got Bool
+
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Bool %s: %v", tt.in, err)
+		} else if got != tt.want {
+			t.Errorf("Bool %s = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{`2`, `"maybe"`} {
+		var got Bool
+
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("Bool %s succeeded, want an error", in)
+		}
+	}
+}
+
+func TestIntUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Int
+	}{
+		{`42`, 42},
+		{`-7`, -7},
+		{`"42"`, 42},
+		{`" 42 "`, 42},
+		{`1.5e3`, 1500},
+		{`"2147483648.0"`, 2147483648},
+		{`""`, 0},
+		{`null`, 0},
+	}
+
+	for _, tt := range tests {
+		var got Int
+
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Int %s: %v", tt.in, err)
+		} else if got != tt.want {
+			t.Errorf("Int %s = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{`"abc"`, `true`} {
+		var got Int
+
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("Int %s succeeded, want an error", in)
+		}
+	}
+}
+
+func TestFloatUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Float
+	}{
+		{`0.25`, 0.25},
+		{`3`, 3},
+		{`"0.25"`, 0.25},
+		{`"1e2"`, 100},
+		{`""`, 0},
+		{`null`, 0},
+	}
+
+	for _, tt := range tests {
+		var got Float
+
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Float %s: %v", tt.in, err)
+		} else if got != tt.want {
+			t.Errorf("Float %s = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	var got Float
+
+	if err := json.Unmarshal([]byte(`"n/a"`), &got); err == nil {
+		t.Errorf(`Float "n/a" succeeded, want an error`)
+	}
+}
+
+func TestListUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want List
+	}{
+		{`"prod;web"`, List{"prod", "web"}},
+		{`"images,iso, backup"`, List{"images", "iso", "backup"}},
+		{`"a;b,c"`, List{"a", "b", "c"}},
+		{`"single"`, List{"single"}},
+		{`";;a;;"`, List{"a"}},
+		{`["x", "y"]`, List{"x", "y"}},
+		{`["x", 1, true]`, List{"x", "1", "true"}},
+		{`[]`, List{}},
+		{`""`, nil},
+		{`null`, nil},
+	}
+
+	for _, tt := range tests {
+		got := List{"stale"}
+
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("List %s: %v", tt.in, err)
+		} else if !slices.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+			t.Errorf("List %s = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStringUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want String
+	}{
+		{`"pve1"`, "pve1"},
+		{`100`, "100"},
+		{`1.5`, "1.5"},
+		{`true`, "true"},
+		{`null`, ""},
+	}
+
+	for _, tt := range tests {
+		var got String
+
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("String %s: %v", tt.in, err)
+		} else if got != tt.want {
+			t.Errorf("String %s = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTypesInStruct(t *testing.T) {
+	var v struct {
+		VMID     Int   `json:"vmid"`
+		CPU      Float `json:"cpu"`
+		Template Bool  `json:"template"`
+		Tags     List  `json:"tags"`
+	}
+
+	in := `{"vmid": "100", "cpu": "0.5", "template": 1, "tags": "prod;web"}`
+
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if v.VMID != 100 || v.CPU != 0.5 || !bool(v.Template) || !slices.Equal(v.Tags, List{"prod", "web"}) {
+		t.Errorf("decoded %+v", v)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"vmid":100,"cpu":0.5,"template":true,"tags":["prod","web"]}`; string(out) != want {
+		t.Errorf("encoded %s, want %s", out, want)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UPID is a decoded Proxmox task identifier of the form
+// UPID:node:pid:pstart:starttime:type:id:user:
+type UPID struct {
+	Raw       string    `json:"upid"`
+	Node      string    `json:"node"`
+	PID       int64     `json:"pid"`
+	PStart    int64     `json:"pstart"`
+	StartTime time.Time `json:"starttime"`
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	User      string    `json:"user"`
+}
+
+// ParseUPID splits a task UPID into its fields.
+func ParseUPID(s string) (*UPID, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, ":")
+
+	// The trailing colon yields an empty ninth element.
+	if len(parts) < 8 || parts[0] != "UPID" {
+		return nil, fmt.Errorf("invalid UPID %q", s)
+	}
+
+	pid, err := strconv.ParseInt(parts[2], 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid UPID %q: bad pid: %w", s, err)
+	}
+
+	pstart, err := strconv.ParseInt(parts[3], 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid UPID %q: bad process start: %w", s, err)
+	}
+
+	start, err := strconv.ParseInt(parts[4], 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid UPID %q: bad start time: %w", s, err)
+	}
+
+	return &UPID{
+		Raw:       s,
+		Node:      parts[1],
+		PID:       pid,
+		PStart:    pstart,
+		StartTime: time.Unix(start, 0),
+		Type:      parts[5],
+		ID:        parts[6],
+		User:      parts[7],
+	}, nil
+}
+
+func (u *UPID) String() string {
+	return u.Raw
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package proxmox
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseUPID(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want UPID
+	}{
+		{
+			name: "vm task",
+			in:   "UPID:pve1:000A1B2C:0BADF00D:65A1B2C3:qmstart:100:root@pam:",
+			want: UPID{Node: "pve1", PID: 0xA1B2C, PStart: 0xBADF00D, StartTime: time.Unix(0x65A1B2C3, 0), Type: "qmstart", ID: "100", User: "root@pam"},
+		},
+		{
+			name: "empty id",
+			in:   "UPID:pve2:00001234:00005678:6500000A:vzdump::backup@pve!job:",
+			want: UPID{Node: "pve2", PID: 0x1234, PStart: 0x5678, StartTime: time.Unix(0x6500000A, 0), Type: "vzdump", User: "backup@pve!job"},
+		},
+		{
+			name: "lowercase hex and surrounding space",
+			in:   "  UPID:pve3:00abcdef:0000ffff:65ffffff:aptupdate::root@pam:\n",
+			want: UPID{Node: "pve3", PID: 0xabcdef, PStart: 0xffff, StartTime: time.Unix(0x65ffffff, 0), Type: "aptupdate", User: "root@pam"},
+		},
+		{
+			name: "no trailing colon",
+			in:   "UPID:pve1:1:2:3:vncproxy:101:alice@pve",
+			want: UPID{Node: "pve1", PID: 1, PStart: 2, StartTime: time.Unix(3, 0), Type: "vncproxy", ID: "101", User: "alice@pve"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUPID(tt.in)
+			if err != nil {
+				t.Fatalf("ParseUPID(%q): %v", tt.in, err)
+			}
+
+			tt.want.Raw = strings.TrimSpace(tt.in)
+
+			if *got != tt.want {
+				t.Errorf("ParseUPID(%q) = %+v, want %+v", tt.in, *got, tt.want)
+			}
+
+			if got.String() != tt.want.Raw {
+				t.Errorf("String() = %q, want %q", got.String(), tt.want.Raw)
+			}
+		})
+	}
+}
+
+func TestParseUPIDInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"not a upid",
+		"UPID:pve1:000A1B2C:0BADF00D:65A1B2C3:qmstart:100",
+		"TASK:pve1:000A1B2C:0BADF00D:65A1B2C3:qmstart:100:root@pam:",
+		"UPID:pve1:xyz:0BADF00D:65A1B2C3:qmstart:100:root@pam:",
+		"UPID:pve1:000A1B2C:nope:65A1B2C3:qmstart:100:root@pam:",
+		"UPID:pve1:000A1B2C:0BADF00D:when:qmstart:100:root@pam:",
+	}
+
+	for _, in := range tests {
+		if u, err := ParseUPID(in); err == nil {
+			t.Errorf("ParseUPID(%q) = %+v, want an error", in, *u)
+		}
+	}
+}